@@ -0,0 +1,77 @@
+// Package apitest runs a local server replaying recorded transport.opendata.ch
+// responses, for tests exercising the real client without network.
+package apitest
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Server answers each endpoint with the file of the same name, e.g.
+// /connections with connections.json.
+type Server struct {
+	*httptest.Server
+
+	fsys     fs.FS
+	mu       sync.Mutex
+	requests []*url.URL
+	failures []int
+}
+
+// NewServer starts a server replaying the responses in fsys. Close it when
+// done.
+func NewServer(fsys fs.FS) *Server {
+	s := &Server{fsys: fsys}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Fail makes the next requests answer with the given statuses, one each,
+// before replaying responses again.
+func (s *Server) Fail(statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statuses...)
+}
+
+// Requests lists the URLs requested so far.
+func (s *Server) Requests() []*url.URL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL)
+	status := 0
+	if len(s.failures) > 0 {
+		status, s.failures = s.failures[0], s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if status != 0 {
+		writeError(w, status, http.StatusText(status))
+		return
+	}
+
+	b, err := fs.ReadFile(s.fsys, strings.TrimPrefix(r.URL.Path, "/")+".json")
+	if err != nil {
+		writeError(w, http.StatusNotFound, "no recorded response for "+r.URL.Path)
+		return
+	}
+	w.Write(b)
+}
+
+// writeError answers the way the API does.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"errors":[{"message":%q}]}`, message)
+}
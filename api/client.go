@@ -2,48 +2,247 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"sbb-tui/models"
 	"sbb-tui/utils"
 )
 
-func FetchConnections(from, to, date, timeStr string, isArrivalTime bool, limit int) ([]models.Connection, error) {
+const (
+	DefaultBaseURL    = "https://transport.opendata.ch/v1"
+	DefaultTimeout    = 10 * time.Second
+	DefaultUserAgent  = "sbb-tui"
+	DefaultMaxRetries = 3
+
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 8 * time.Second
+)
+
+type Client struct {
+	BaseURL    string
+	UserAgent  string
+	MaxRetries int
+	HTTPClient *http.Client
+
+	// Doubled after every retry, unless the server sends Retry-After
+	RetryDelay time.Duration
+}
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		UserAgent:  DefaultUserAgent,
+		MaxRetries: DefaultMaxRetries,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		RetryDelay: retryBaseDelay,
+	}
+}
+
+func (c *Client) Connections(ctx context.Context, input models.Input) ([]models.Connection, error) {
 	parts := []string{
-		fmt.Sprintf("from=%s", url.QueryEscape(from)),
-		fmt.Sprintf("to=%s", url.QueryEscape(to)),
+		fmt.Sprintf("from=%s", url.QueryEscape(input.From)),
+		fmt.Sprintf("to=%s", url.QueryEscape(input.To)),
 	}
 
-	if date != "" {
-		parts = append(parts, fmt.Sprintf("date=%s", url.QueryEscape(date)))
+	for _, via := range input.Via {
+		parts = append(parts, fmt.Sprintf("via[]=%s", url.QueryEscape(via)))
 	}
 
-	if timeStr != "" {
-		parts = append(parts, fmt.Sprintf("time=%s", url.QueryEscape(timeStr)))
+	if !input.Date.IsZero() {
+		parts = append(parts, fmt.Sprintf("date=%s", input.Date.Format("2006-01-02")))
 	}
 
+	if !input.Time.IsZero() {
+		parts = append(parts, fmt.Sprintf("time=%s", url.QueryEscape(input.Time.Format("15:04"))))
+	}
+
+	parts = append(parts, transportationsQuery(input.Transportations)...)
 	parts = append(parts,
-		fmt.Sprintf("isArrivalTime=%s", strconv.Itoa(utils.Btoi(isArrivalTime))),
-		fmt.Sprintf("limit=%v", limit),
+		fmt.Sprintf("isArrivalTime=%s", strconv.Itoa(utils.Btoi(input.IsArrivalTime))),
+		fmt.Sprintf("limit=%v", input.Limit),
 	)
 
-	apiURL := "https://transport.opendata.ch/v1/connections?" + strings.Join(parts, "&")
-
-	resp, err := http.Get(apiURL)
-	if err != nil {
-		return nil, err
+	if input.Page != 0 {
+		parts = append(parts, fmt.Sprintf("page=%d", input.Page))
 	}
-	defer resp.Body.Close()
 
 	var result models.APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.get(ctx, "/connections", parts, &result); err != nil {
 		return nil, err
 	}
 
 	return result.Connections, nil
 }
+
+func (c *Client) Locations(ctx context.Context, query string) ([]models.Location, error) {
+	parts := []string{
+		fmt.Sprintf("query=%s", url.QueryEscape(query)),
+		"type=station",
+	}
+
+	var result models.LocationsResponse
+	if err := c.get(ctx, "/locations", parts, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Stations, nil
+}
+
+func (c *Client) Stationboard(ctx context.Context, input models.Input) ([]models.StationboardEntry, error) {
+	boardType := "departure"
+	if input.IsArrivalTime {
+		boardType = "arrival"
+	}
+
+	parts := []string{
+		fmt.Sprintf("station=%s", url.QueryEscape(input.From)),
+		fmt.Sprintf("type=%s", boardType),
+		fmt.Sprintf("limit=%v", input.Limit),
+	}
+
+	if !input.Date.IsZero() || !input.Time.IsZero() {
+		// The endpoint wants a full datetime, default to today
+		date := input.Date
+		if date.IsZero() {
+			date = time.Now()
+		}
+		datetime := date.Format("2006-01-02")
+		if !input.Time.IsZero() {
+			datetime += " " + input.Time.Format("15:04")
+		}
+		parts = append(parts, fmt.Sprintf("datetime=%s", url.QueryEscape(datetime)))
+	}
+
+	parts = append(parts, transportationsQuery(input.Transportations)...)
+
+	var result models.StationboardResponse
+	if err := c.get(ctx, "/stationboard", parts, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Stationboard, nil
+}
+
+func transportationsQuery(transportations []string) []string {
+	var parts []string
+	for _, t := range transportations {
+		parts = append(parts, fmt.Sprintf("transportations[]=%s", url.QueryEscape(t)))
+	}
+	return parts
+}
+
+// get requests the endpoint and decodes its JSON answer into v, retrying
+// with exponential backoff while the API is rate limiting or failing.
+// Cancelling ctx aborts both the request and the wait between retries.
+func (c *Client) get(ctx context.Context, endpoint string, parts []string, v any) error {
+	apiURL := c.BaseURL + endpoint + "?" + strings.Join(parts, "&")
+
+	delay := c.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err := c.do(ctx, apiURL, v)
+		if err == nil || !isRetryable(err) || attempt >= c.MaxRetries {
+			return err
+		}
+
+		wait := delay
+		if rl, ok := err.(*RateLimitedError); ok && rl.RetryAfter > 0 {
+			wait = rl.RetryAfter
+		}
+
+		timer := time.NewTimer(min(wait, retryMaxDelay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+func (c *Client) do(ctx context.Context, apiURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return &NetworkError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	if err := statusError(resp); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding API response: %w", err)
+	}
+	return nil
+}
+
+// statusError turns a non-200 response into one of the typed errors.
+func statusError(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &RateLimitedError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+
+	case resp.StatusCode >= 500:
+		return &ServerError{StatusCode: resp.StatusCode}
+	}
+
+	return &BadRequestError{StatusCode: resp.StatusCode, Message: errorMessage(resp.Body)}
+}
+
+// errorMessage extracts the API's explanation from an error body.
+func errorMessage(body io.Reader) string {
+	b, err := io.ReadAll(io.LimitReader(body, 4096))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(b, &payload) == nil {
+		if len(payload.Errors) > 0 {
+			return payload.Errors[0].Message
+		}
+		if payload.Error != "" {
+			return payload.Error
+		}
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
@@ -0,0 +1,131 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"sbb-tui/api"
+	"sbb-tui/api/apitest"
+	"sbb-tui/api/fixtures"
+	"sbb-tui/models"
+)
+
+func newClient(t *testing.T) (*api.Client, *apitest.Server) {
+	t.Helper()
+	srv := apitest.NewServer(fixtures.FS())
+	t.Cleanup(srv.Close)
+
+	c := api.NewClient(srv.URL)
+	c.RetryDelay = time.Millisecond
+	return c, srv
+}
+
+func TestConnections(t *testing.T) {
+	c, srv := newClient(t)
+
+	input := models.Input{
+		From:  "Bern",
+		To:    "Zürich HB",
+		Via:   []string{"Olten"},
+		Date:  time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local),
+		Time:  time.Date(2026, 10, 16, 8, 0, 0, 0, time.Local),
+		Limit: 4,
+	}
+	connections, err := c.Connections(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connections) != 4 {
+		t.Fatalf("got %d connections, want 4", len(connections))
+	}
+	if got := connections[1].Sections[0].Journey.Category; got != "IR" {
+		t.Errorf("second connection starts with %q, want IR", got)
+	}
+
+	q := srv.Requests()[0].Query()
+	for key, want := range map[string]string{
+		"from": "Bern", "to": "Zürich HB", "via[]": "Olten",
+		"date": "2026-10-16", "time": "08:00", "limit": "4",
+	} {
+		if got := q.Get(key); got != want {
+			t.Errorf("query %s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLocationsAndStationboard(t *testing.T) {
+	c, _ := newClient(t)
+	ctx := context.Background()
+
+	locations, err := c.Locations(ctx, "Bern")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) == 0 || locations[0].Name != "Bern" {
+		t.Errorf("locations = %v, want Bern first", locations)
+	}
+
+	board, err := c.Stationboard(ctx, models.Input{From: "Bern", Limit: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(board) == 0 || board[0].Stop.Station.Name != "Bern" {
+		t.Errorf("stationboard = %v, want departures from Bern", board)
+	}
+}
+
+func TestRetriesServerErrors(t *testing.T) {
+	c, srv := newClient(t)
+	srv.Fail(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+	if _, err := c.Connections(context.Background(), models.Input{From: "Bern", To: "Thun"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(srv.Requests()); got != 3 {
+		t.Errorf("made %d requests, want 3", got)
+	}
+}
+
+func TestGivesUpAfterRetries(t *testing.T) {
+	c, srv := newClient(t)
+	c.MaxRetries = 1
+	srv.Fail(http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+
+	_, err := c.Connections(context.Background(), models.Input{From: "Bern", To: "Thun"})
+	var serverErr *api.ServerError
+	if !errors.As(err, &serverErr) || serverErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("err = %v, want a 502 ServerError", err)
+	}
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("made %d requests, want 2", got)
+	}
+}
+
+func TestBadRequestIsNotRetried(t *testing.T) {
+	c, srv := newClient(t)
+	srv.Fail(http.StatusBadRequest)
+
+	_, err := c.Connections(context.Background(), models.Input{From: "Bern", To: "Thun"})
+	var badRequest *api.BadRequestError
+	if !errors.As(err, &badRequest) || badRequest.Message != "Bad Request" {
+		t.Fatalf("err = %v, want a BadRequestError with the API message", err)
+	}
+	if got := len(srv.Requests()); got != 1 {
+		t.Errorf("made %d requests, want 1", got)
+	}
+}
+
+func TestCancelStopsRetrying(t *testing.T) {
+	c, srv := newClient(t)
+	c.RetryDelay = time.Hour
+	srv.Fail(http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Connections(ctx, models.Input{From: "Bern", To: "Thun"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want the deadline", err)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// NetworkError means the API could not be reached at all.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string { return "network error: " + e.Err.Error() }
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited by the API, retry after %s", e.RetryAfter)
+	}
+	return "rate limited by the API"
+}
+
+type ServerError struct {
+	StatusCode int
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("API server error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// BadRequestError carries the API's message about a rejected request.
+type BadRequestError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *BadRequestError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("request rejected by the API: %s", e.Message)
+	}
+	return fmt.Sprintf("request rejected by the API: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func isRetryable(err error) bool {
+	switch err.(type) {
+	case *RateLimitedError, *ServerError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+// Package fixtures answers timetable queries from recorded API responses,
+// so the interface runs without network.
+package fixtures
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"sbb-tui/api"
+	"sbb-tui/models"
+)
+
+//go:embed responses/*.json
+var responses embed.FS
+
+// FS holds the recorded answers, one file per endpoint: connections.json,
+// locations.json and stationboard.json.
+func FS() fs.FS {
+	sub, _ := fs.Sub(responses, "responses")
+	return sub
+}
+
+// Provider replays the recorded answers. Connections and locations are
+// filtered by the station names in the query, a part of the name being
+// enough, and the limit applies. Everything else in the query is ignored.
+type Provider struct {
+	FS fs.FS
+}
+
+var _ api.Provider = (*Provider)(nil)
+
+func New() *Provider {
+	return &Provider{FS: FS()}
+}
+
+func (p *Provider) Connections(ctx context.Context, input models.Input) ([]models.Connection, error) {
+	// Everything recorded fits on the first page
+	if input.Page != 0 {
+		return nil, ctx.Err()
+	}
+
+	var result models.APIResponse
+	if err := p.load(ctx, "connections.json", &result); err != nil {
+		return nil, err
+	}
+	var matches []models.Connection
+	for _, c := range result.Connections {
+		if matchesName(c.FromData.Station.Name, input.From) && matchesName(c.ToData.Station.Name, input.To) {
+			matches = append(matches, c)
+		}
+	}
+	return limit(matches, input.Limit), nil
+}
+
+func (p *Provider) Locations(ctx context.Context, query string) ([]models.Location, error) {
+	var result models.LocationsResponse
+	if err := p.load(ctx, "locations.json", &result); err != nil {
+		return nil, err
+	}
+
+	var matches []models.Location
+	for _, l := range result.Stations {
+		if matchesName(l.Name, query) {
+			matches = append(matches, l)
+		}
+	}
+	return matches, nil
+}
+
+func (p *Provider) Stationboard(ctx context.Context, input models.Input) ([]models.StationboardEntry, error) {
+	var result models.StationboardResponse
+	if err := p.load(ctx, "stationboard.json", &result); err != nil {
+		return nil, err
+	}
+	return limit(result.Stationboard, input.Limit), nil
+}
+
+func (p *Provider) load(ctx context.Context, name string, v any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b, err := fs.ReadFile(p.FS, name)
+	if err != nil {
+		return fmt.Errorf("reading fixture: %w", err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("decoding fixture %s: %w", name, err)
+	}
+	return nil
+}
+
+func matchesName(name, query string) bool {
+	return strings.Contains(strings.ToLower(name), strings.ToLower(strings.TrimSpace(query)))
+}
+
+func limit[T any](items []T, n int) []T {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
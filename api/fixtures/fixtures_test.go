@@ -0,0 +1,49 @@
+package fixtures
+
+import (
+	"context"
+	"testing"
+
+	"sbb-tui/models"
+)
+
+func TestProvider(t *testing.T) {
+	p := New()
+	ctx := context.Background()
+
+	connections, err := p.Connections(ctx, models.Input{From: "Bern", To: "Zürich HB", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connections) != 2 {
+		t.Errorf("got %d connections, want the limit of 2", len(connections))
+	}
+
+	later, err := p.Connections(ctx, models.Input{From: "Bern", To: "Zürich HB", Page: 1})
+	if err != nil || len(later) != 0 {
+		t.Errorf("page 1 = %d connections, %v, want none", len(later), err)
+	}
+
+	other, err := p.Connections(ctx, models.Input{From: "Basel SBB", To: "Zürich HB"})
+	if err != nil || len(other) != 0 {
+		t.Errorf("Basel SBB to Zürich HB = %d connections, %v, want none", len(other), err)
+	}
+	partial, err := p.Connections(ctx, models.Input{From: "bern", To: "zürich"})
+	if err != nil || len(partial) == 0 {
+		t.Errorf("bern to zürich = %d connections, %v, want the recorded ones", len(partial), err)
+	}
+
+	locations, err := p.Locations(ctx, "bern")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 2 {
+		t.Errorf("got %d locations for bern, want Bern and Bern Wankdorf", len(locations))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := p.Stationboard(ctx, models.Input{From: "Bern"}); err != context.Canceled {
+		t.Errorf("err = %v after cancelling, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+
+	"sbb-tui/models"
+)
+
+// Provider answers the timetable queries of the interface. Client is the
+// transport.opendata.ch implementation.
+type Provider interface {
+	Connections(ctx context.Context, input models.Input) ([]models.Connection, error)
+	Locations(ctx context.Context, query string) ([]models.Location, error)
+	Stationboard(ctx context.Context, input models.Input) ([]models.StationboardEntry, error)
+}
+
+var _ Provider = (*Client)(nil)
@@ -0,0 +1,360 @@
+// Package cli
+package cli
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"sbb-tui/api"
+	"sbb-tui/config"
+	"sbb-tui/models"
+	"sbb-tui/utils"
+)
+
+const (
+	// Exit codes
+	ExitOK = iota
+	ExitError
+	ExitUsage
+	ExitNoResults
+)
+
+const (
+	// Output formats
+	formatPlain = "plain"
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// Usage lists the commands and the flags shared by all of them.
+const Usage = `Usage:
+  sbb-tui                 start the interactive timetable
+  sbb-tui conn [flags]    print connections between two stations
+  sbb-tui board [flags]   print the departure board of a station
+  sbb-tui --version       print the version
+
+Flags:
+  --icons set             icon set: auto, nerdfont, unicode or ascii
+
+Run "sbb-tui <command> -h" for the flags of a command.
+`
+
+// Run executes a headless command against the provider and returns the
+// process exit code.
+func Run(args []string, cfg config.Config, provider api.Provider, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, Usage)
+		return ExitUsage
+	}
+
+	switch args[0] {
+	case "conn", "connections":
+		return runConnections(args[1:], cfg, provider, stdout, stderr)
+	case "board", "stationboard":
+		return runBoard(args[1:], cfg, provider, stdout, stderr)
+	case "-h", "--help", "help":
+		fmt.Fprint(stdout, Usage)
+		return ExitOK
+	}
+
+	fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], Usage)
+	return ExitUsage
+}
+
+// queryFlags are shared by every command hitting the timetable.
+type queryFlags struct {
+	date      string
+	at        string
+	arrival   bool
+	transport string
+	limit     int
+	format    string
+}
+
+func (q *queryFlags) register(fs *flag.FlagSet, defaultLimit int) {
+	fs.StringVar(&q.date, "date", "", "travel date as YYYY-MM-DD, 16.10., tomorrow or fri (default today)")
+	fs.StringVar(&q.at, "at", "", "travel time as HH:MM, +45m or in 2h (default now)")
+	fs.BoolVar(&q.arrival, "arrival", false, "treat --at as the arrival time")
+	fs.StringVar(&q.transport, "transport", "", "comma separated transportations: "+strings.Join(models.Transportations, ", "))
+	fs.IntVar(&q.limit, "limit", defaultLimit, "number of results")
+	fs.StringVar(&q.format, "format", formatPlain, "output format: plain, table or json")
+}
+
+func (q queryFlags) input() (models.Input, error) {
+	input := models.Input{
+		IsArrivalTime: q.arrival,
+		Limit:         q.limit,
+	}
+
+	if q.limit < 1 {
+		return input, errors.New("--limit must be positive")
+	}
+
+	switch q.format {
+	case formatPlain, formatTable, formatJSON:
+	default:
+		return input, fmt.Errorf("unknown format %q", q.format)
+	}
+
+	now := time.Now()
+	date, err := utils.ParseDate(q.date, now)
+	if err != nil {
+		return input, fmt.Errorf("invalid --date %q, expected YYYY-MM-DD, 16.10., tomorrow or a weekday", q.date)
+	}
+	at, err := utils.ParseWhen(q.at, now)
+	if err != nil {
+		return input, fmt.Errorf("invalid --at %q, expected HH:MM, now, +45m or in 2h", q.at)
+	}
+	when := date.Merge(at)
+	if when.HasDate {
+		input.Date = when.Date
+	}
+	if when.HasTime {
+		input.Time = when.Time
+	}
+
+	for _, t := range splitList(q.transport) {
+		if !slices.Contains(models.Transportations, t) {
+			return input, fmt.Errorf("unknown transportation %q", t)
+		}
+		input.Transportations = append(input.Transportations, t)
+	}
+
+	return input, nil
+}
+
+func runConnections(args []string, cfg config.Config, provider api.Provider, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("conn", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var q queryFlags
+	from := fs.String("from", cfg.Defaults.From, "departure station (required)")
+	to := fs.String("to", "", "arrival station (required)")
+	via := fs.String("via", "", fmt.Sprintf("comma separated via stations, at most %d", models.MaxVia))
+	q.register(fs, cmp.Or(cfg.Defaults.Limit, 4))
+
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+
+	input, err := q.input()
+	if err == nil && (*from == "" || *to == "") {
+		err = errors.New("--from and --to are required")
+	}
+	input.From, input.To = *from, *to
+	input.Via = splitList(*via)
+	if err == nil && len(input.Via) > models.MaxVia {
+		err = fmt.Errorf("at most %d --via stations are allowed", models.MaxVia)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "sbb-tui conn:", err)
+		return ExitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	connections, err := provider.Connections(ctx, input)
+	if err != nil {
+		fmt.Fprintln(stderr, "sbb-tui conn:", err)
+		return ExitError
+	}
+
+	if err := printConnections(stdout, q.format, connections); err != nil {
+		fmt.Fprintln(stderr, "sbb-tui conn:", err)
+		return ExitError
+	}
+
+	if len(connections) == 0 {
+		return ExitNoResults
+	}
+	return ExitOK
+}
+
+func runBoard(args []string, cfg config.Config, provider api.Provider, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("board", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var q queryFlags
+	station := fs.String("station", cfg.Defaults.From, "station of the board (required)")
+	q.register(fs, 10)
+
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+
+	input, err := q.input()
+	if err == nil && *station == "" {
+		err = errors.New("--station is required")
+	}
+	input.From = *station
+	if err != nil {
+		fmt.Fprintln(stderr, "sbb-tui board:", err)
+		return ExitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	entries, err := provider.Stationboard(ctx, input)
+	if err != nil {
+		fmt.Fprintln(stderr, "sbb-tui board:", err)
+		return ExitError
+	}
+
+	if err := printBoard(stdout, q.format, entries, q.arrival); err != nil {
+		fmt.Fprintln(stderr, "sbb-tui board:", err)
+		return ExitError
+	}
+
+	if len(entries) == 0 {
+		return ExitNoResults
+	}
+	return ExitOK
+}
+
+func parseExit(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	return ExitUsage
+}
+
+func printConnections(w io.Writer, format string, connections []models.Connection) error {
+	switch format {
+	case formatJSON:
+		// Scripts expect a list, even an empty one
+		if connections == nil {
+			connections = []models.Connection{}
+		}
+		return writeJSON(w, connections)
+
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "DEPARTURE\tARRIVAL\tDURATION\tTRANSFERS\tPLATFORM\tVEHICLES")
+		for _, c := range connections {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n",
+				formatTime(c.FromData.Departure, c.FromData.Delay),
+				formatTime(c.ToData.Arrival, 0),
+				utils.FormatDuration(c.Duration),
+				c.Transfers,
+				c.FromData.Platform,
+				strings.Join(vehicles(c), ", "),
+			)
+		}
+		return tw.Flush()
+	}
+
+	for _, c := range connections {
+		platform := ""
+		if c.FromData.Platform != "" {
+			platform = ", platform " + c.FromData.Platform
+		}
+		_, err := fmt.Fprintf(w, "%s %s -> %s %s (%s, %d transfers%s) %s\n",
+			formatTime(c.FromData.Departure, c.FromData.Delay),
+			c.FromData.Station.Name,
+			formatTime(c.ToData.Arrival, 0),
+			c.ToData.Station.Name,
+			utils.FormatDuration(c.Duration),
+			c.Transfers,
+			platform,
+			strings.Join(vehicles(c), ", "),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printBoard(w io.Writer, format string, entries []models.StationboardEntry, isArrivalTime bool) error {
+	boardTime := func(e models.StationboardEntry) models.SBBDateLayout {
+		if isArrivalTime && !e.Stop.Arrival.IsZero() {
+			return e.Stop.Arrival
+		}
+		return e.Stop.Departure
+	}
+
+	switch format {
+	case formatJSON:
+		if entries == nil {
+			entries = []models.StationboardEntry{}
+		}
+		return writeJSON(w, entries)
+
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TIME\tVEHICLE\tDESTINATION\tPLATFORM")
+		for _, e := range entries {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+				formatTime(boardTime(e), e.Stop.Delay),
+				strings.TrimSpace(e.Category+" "+e.Number),
+				e.To,
+				e.Stop.Platform,
+			)
+		}
+		return tw.Flush()
+	}
+
+	for _, e := range entries {
+		platform := ""
+		if e.Stop.Platform != "" {
+			platform = " (platform " + e.Stop.Platform + ")"
+		}
+		_, err := fmt.Fprintf(w, "%s %s -> %s%s\n",
+			formatTime(boardTime(e), e.Stop.Delay),
+			strings.TrimSpace(e.Category+" "+e.Number),
+			e.To,
+			platform,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func formatTime(t models.SBBDateLayout, delay int) string {
+	s := t.Local().Format("15:04")
+	if delay > 0 {
+		s += fmt.Sprintf(" +%d", delay)
+	}
+	return s
+}
+
+func vehicles(c models.Connection) []string {
+	var names []string
+	for _, s := range c.Sections {
+		if s.Journey != nil {
+			names = append(names, strings.TrimSpace(s.Journey.Category+" "+s.Journey.Number))
+		}
+	}
+	return names
+}
+
+func splitList(s string) []string {
+	var items []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
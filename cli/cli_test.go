@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"sbb-tui/api/fixtures"
+	"sbb-tui/config"
+	"sbb-tui/models"
+)
+
+// emptyProvider finds nothing at all.
+type emptyProvider struct{}
+
+func (emptyProvider) Connections(context.Context, models.Input) ([]models.Connection, error) {
+	return nil, nil
+}
+
+func (emptyProvider) Locations(context.Context, string) ([]models.Location, error) {
+	return nil, nil
+}
+
+func (emptyProvider) Stationboard(context.Context, models.Input) ([]models.StationboardEntry, error) {
+	return nil, nil
+}
+
+func TestRunOffline(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"conn", "--from", "Bern", "--to", "Zürich HB", "--limit", "2"}, ExitOK, "IC 1"},
+		{[]string{"board", "--station", "Bern", "--format", "json"}, ExitOK, `"St. Gallen"`},
+		{[]string{"conn", "--from", "Bern"}, ExitUsage, ""},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		code := Run(tt.args, config.Default(), fixtures.New(), &stdout, &stderr)
+		if code != tt.code {
+			t.Errorf("%v exited with %d, want %d (%s)", tt.args, code, tt.code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), tt.want) {
+			t.Errorf("%v printed %q, want %q in it", tt.args, stdout.String(), tt.want)
+		}
+	}
+}
+
+func TestRunEmptyJSON(t *testing.T) {
+	for _, args := range [][]string{
+		{"conn", "--from", "Bern", "--to", "Thun", "--format", "json"},
+		{"board", "--station", "Bern", "--format", "json"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := Run(args, config.Default(), emptyProvider{}, &stdout, &stderr); code != ExitNoResults {
+			t.Errorf("%v exited with %d, want %d (%s)", args, code, ExitNoResults, stderr.String())
+		}
+		if got := strings.TrimSpace(stdout.String()); got != "[]" {
+			t.Errorf("%v printed %q, want []", args, got)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+// Package config
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sbb-tui/api"
+	"sbb-tui/models"
+
+	"github.com/BurntSushi/toml"
+)
+
+const appName = "sbb-tui"
+
+const (
+	// Timetable providers
+	ProviderOpendata = "opendata"
+	ProviderFixtures = "fixtures"
+)
+
+// Keeps the live refresh from hammering the API
+const minRefreshInterval = 15 * time.Second
+
+const (
+	// Environment overrides
+	EnvConfig   = "SBB_TUI_CONFIG"
+	EnvAPIURL   = "SBB_TUI_API_URL"
+	EnvProvider = "SBB_TUI_PROVIDER"
+	EnvFrom     = "SBB_TUI_FROM"
+	EnvLimit    = "SBB_TUI_LIMIT"
+	EnvTheme    = "SBB_TUI_THEME"
+	EnvIcons    = "SBB_TUI_ICONS"
+)
+
+type Config struct {
+	API      API                 `toml:"api"`
+	Defaults Defaults            `toml:"defaults"`
+	Cache    Cache               `toml:"cache"`
+	Refresh  Refresh             `toml:"refresh"`
+	Watch    Watch               `toml:"watch"`
+	Theme    Theme               `toml:"theme"`
+	Icons    map[string]string   `toml:"icons"`
+	Keys     map[string][]string `toml:"keys"`
+}
+
+type API struct {
+	Provider  string        `toml:"provider"`
+	URL       string        `toml:"url"`
+	Timeout   time.Duration `toml:"timeout"`
+	Retries   int           `toml:"retries"`
+	UserAgent string        `toml:"user_agent"`
+}
+
+// Cache keeps the last results of each search on disk. Results younger than
+// TTL show up instantly while the search refreshes them, older ones only
+// when the API cannot be reached.
+type Cache struct {
+	Enabled    bool          `toml:"enabled"`
+	TTL        time.Duration `toml:"ttl"`
+	MaxEntries int           `toml:"max_entries"`
+}
+
+// Refresh re-runs the current search every Interval to keep delays and
+// platforms up to date, zero turns it off.
+type Refresh struct {
+	Interval time.Duration `toml:"interval"`
+}
+
+// Watch polls the watched connection every Interval and alerts when its
+// departure is DelayThreshold minutes late or a platform changes.
+type Watch struct {
+	Interval       time.Duration `toml:"interval"`
+	DelayThreshold int           `toml:"delay_threshold"`
+	Bell           bool          `toml:"bell"`
+	Desktop        bool          `toml:"desktop"`
+}
+
+type Defaults struct {
+	From        string `toml:"from"`
+	Limit       int    `toml:"limit"`
+	HideCrowded bool   `toml:"hide_crowded"`
+}
+
+// Theme picks one of the built-in palettes, each color can be overridden
+// with a hex code or an ANSI color number.
+type Theme struct {
+	Name       string `toml:"name"`
+	Accent     string `toml:"accent"`
+	AccentDark string `toml:"accent_dark"`
+	Vehicle    string `toml:"vehicle"`
+	Border     string `toml:"border"`
+	Foreground string `toml:"foreground"`
+	Background string `toml:"background"`
+	Muted      string `toml:"muted"`
+}
+
+var Themes = map[string]Theme{
+	"sbb": {
+		Accent:     "#D82E20",
+		AccentDark: "#862010",
+		Vehicle:    "#2E3279",
+		Border:     "#484848",
+		Foreground: "#FFFFFF",
+		Background: "#141414",
+		Muted:      "#888888",
+	},
+	"mono": {
+		Accent:     "#DDDDDD",
+		AccentDark: "#888888",
+		Vehicle:    "#484848",
+		Border:     "#333333",
+		Foreground: "#141414",
+		Background: "#F6F6F6",
+		Muted:      "#888888",
+	},
+}
+
+// Icons that can be overridden from the [icons] table, next to the set
+// they are taken from.
+var IconNames = []string{
+	"arrival", "board", "connections", "departure", "platform", "prompt",
+	"search", "swap", "vehicle", "walk", "warning", "watch",
+	"arrow", "dot", "hollow_dot", "hline", "vline", "capacity_low", "capacity_medium",
+	"capacity_high", "scroll_track", "scroll_thumb", "separator", "marker",
+}
+
+// Actions that can be rebound from the [keys] table.
+var KeyActions = []string{
+	"quit", "soft_quit", "close", "cancel", "search", "toggle", "next", "prev",
+	"up", "down", "left", "right", "page_up", "page_down", "home", "end",
+	"save_favorite", "favorites", "delete", "history", "watch", "stops",
+	"hide_crowded", "picker",
+}
+
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+func Default() Config {
+	return Config{
+		API: API{
+			Provider: ProviderOpendata,
+			URL:      api.DefaultBaseURL,
+			Timeout:  api.DefaultTimeout,
+			Retries:  api.DefaultMaxRetries,
+		},
+		Cache: Cache{
+			Enabled:    true,
+			TTL:        15 * time.Minute,
+			MaxEntries: 200,
+		},
+		Refresh: Refresh{Interval: time.Minute},
+		Watch: Watch{
+			Interval:       30 * time.Second,
+			DelayThreshold: 3,
+			Bell:           true,
+			Desktop:        true,
+		},
+		Theme: Theme{Name: "sbb"},
+	}
+}
+
+// Load reads the config file, applies the environment overrides and
+// validates the result. A missing file yields the defaults.
+func Load() (Config, error) {
+	cfg := Default()
+
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+
+	meta, err := toml.DecodeFile(path, &cfg)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return cfg, fmt.Errorf("%s: unknown setting %q", path, undecoded[0].String())
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return cfg, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Path returns the config file location, SBB_TUI_CONFIG taking precedence
+// over $XDG_CONFIG_HOME/sbb-tui/config.toml.
+func Path() (string, error) {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path, nil
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		var err error
+		if dir, err = os.UserConfigDir(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(dir, appName, "config.toml"), nil
+}
+
+// DataDir returns the directory for files written by the app, following
+// $XDG_DATA_HOME.
+func DataDir() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, appName), nil
+}
+
+// CacheDir returns the directory for disposable files, following
+// $XDG_CACHE_HOME.
+func CacheDir() (string, error) {
+	dir := os.Getenv("XDG_CACHE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(dir, appName), nil
+}
+
+func (c *Config) applyEnv() error {
+	if url := os.Getenv(EnvAPIURL); url != "" {
+		c.API.URL = url
+	}
+	if provider := os.Getenv(EnvProvider); provider != "" {
+		c.API.Provider = provider
+	}
+	if from := os.Getenv(EnvFrom); from != "" {
+		c.Defaults.From = from
+	}
+	if theme := os.Getenv(EnvTheme); theme != "" {
+		c.Theme.Name = theme
+	}
+	if icons := os.Getenv(EnvIcons); icons != "" {
+		c.SetIconSet(icons)
+	}
+	if limit := os.Getenv(EnvLimit); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a number", EnvLimit, limit)
+		}
+		c.Defaults.Limit = n
+	}
+	return nil
+}
+
+func (c Config) Validate() error {
+	if c.API.Provider != ProviderOpendata && c.API.Provider != ProviderFixtures {
+		return fmt.Errorf("api.provider %q must be %q or %q", c.API.Provider, ProviderOpendata, ProviderFixtures)
+	}
+	if !strings.HasPrefix(c.API.URL, "http://") && !strings.HasPrefix(c.API.URL, "https://") {
+		return fmt.Errorf("api.url %q must be an http(s) URL", c.API.URL)
+	}
+	if c.API.Timeout <= 0 {
+		return fmt.Errorf("api.timeout %s must be positive", c.API.Timeout)
+	}
+	if c.API.Retries < 0 || c.API.Retries > 10 {
+		return fmt.Errorf("api.retries %d must be between 0 and 10", c.API.Retries)
+	}
+
+	if c.Defaults.Limit < 0 || c.Defaults.Limit > models.MaxLimit {
+		return fmt.Errorf("defaults.limit %d must be between 1 and %d, or 0 to fit the screen", c.Defaults.Limit, models.MaxLimit)
+	}
+
+	if c.Cache.TTL < 0 {
+		return fmt.Errorf("cache.ttl %s must not be negative", c.Cache.TTL)
+	}
+	if c.Cache.MaxEntries < 1 {
+		return fmt.Errorf("cache.max_entries %d must be positive", c.Cache.MaxEntries)
+	}
+
+	if c.Refresh.Interval != 0 && c.Refresh.Interval < minRefreshInterval {
+		return fmt.Errorf("refresh.interval %s must be at least %s, or 0 to turn it off", c.Refresh.Interval, minRefreshInterval)
+	}
+
+	if c.Watch.Interval < minRefreshInterval {
+		return fmt.Errorf("watch.interval %s must be at least %s", c.Watch.Interval, minRefreshInterval)
+	}
+	if c.Watch.DelayThreshold < 0 {
+		return fmt.Errorf("watch.delay_threshold %d must not be negative", c.Watch.DelayThreshold)
+	}
+
+	if _, ok := Themes[c.Theme.Name]; !ok {
+		return fmt.Errorf("theme.name %q is not one of %s", c.Theme.Name, strings.Join(themeNames(), ", "))
+	}
+	for name, color := range c.Theme.colors() {
+		if color != "" && !isColor(color) {
+			return fmt.Errorf("theme.%s %q is neither a hex color nor an ANSI color number", name, color)
+		}
+	}
+
+	if set, ok := c.Icons[iconSetKey]; ok && !slices.Contains(iconSetNames(), set) {
+		return fmt.Errorf("icons.set %q is not one of %s", set, strings.Join(iconSetNames(), ", "))
+	}
+	for name := range c.Icons {
+		if name != iconSetKey && !slices.Contains(IconNames, name) {
+			return fmt.Errorf("icons.%s is not an icon, expected one of %s", name, strings.Join(IconNames, ", "))
+		}
+	}
+
+	for action, keys := range c.Keys {
+		if !slices.Contains(KeyActions, action) {
+			return fmt.Errorf("keys.%s is not an action, expected one of %s", action, strings.Join(KeyActions, ", "))
+		}
+		if len(keys) == 0 || slices.Contains(keys, "") {
+			return fmt.Errorf("keys.%s needs at least one non-empty key", action)
+		}
+	}
+
+	return nil
+}
+
+// Palette returns the selected built-in theme with the overrides applied.
+func (t Theme) Palette() Theme {
+	p := Themes[t.Name]
+	p.Name = t.Name
+	override(&p.Accent, t.Accent)
+	override(&p.AccentDark, t.AccentDark)
+	override(&p.Vehicle, t.Vehicle)
+	override(&p.Border, t.Border)
+	override(&p.Foreground, t.Foreground)
+	override(&p.Background, t.Background)
+	override(&p.Muted, t.Muted)
+	return p
+}
+
+func override(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+func (t Theme) colors() map[string]string {
+	return map[string]string{
+		"accent":      t.Accent,
+		"accent_dark": t.AccentDark,
+		"vehicle":     t.Vehicle,
+		"border":      t.Border,
+		"foreground":  t.Foreground,
+		"background":  t.Background,
+		"muted":       t.Muted,
+	}
+}
+
+func isColor(s string) bool {
+	if colorPattern.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+func themeNames() []string {
+	var names []string
+	for name := range Themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"cmp"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	// Icon sets
+	IconSetAuto     = "auto"
+	IconSetNerdfont = "nerdfont"
+	IconSetUnicode  = "unicode"
+	IconSetASCII    = "ascii"
+)
+
+// The [icons] key choosing the set, next to the icon overrides
+const iconSetKey = "set"
+
+// IconSets are the built-in icons, every set covering all of IconNames.
+var IconSets = map[string]map[string]string{
+	IconSetNerdfont: {
+		"arrival":     "󰗔",
+		"board":       "󰅐",
+		"connections": "󰑪",
+		"departure":   "",
+		"platform":    "󱀓",
+		"prompt":      "",
+		"search":      "",
+		"swap":        "",
+		"vehicle":     "",
+		"walk":        "",
+		"warning":     "",
+		"watch":       "",
+
+		// Rail, gauges and separators
+		"arrow":           "→",
+		"dot":             "●",
+		"hollow_dot":      "○",
+		"hline":           "─",
+		"vline":           "│",
+		"capacity_low":    "▂",
+		"capacity_medium": "▄",
+		"capacity_high":   "▆",
+		"scroll_track":    "│",
+		"scroll_thumb":    "┃",
+		"separator":       "·",
+		"marker":          "▌",
+	},
+	IconSetUnicode: {
+		"arrival":     "↘",
+		"board":       "☰",
+		"connections": "⇄",
+		"departure":   "↗",
+		"platform":    "Ⓟ",
+		"prompt":      "›",
+		"search":      "⌕",
+		"swap":        "⇅",
+		"vehicle":     "►",
+		"walk":        "⇢",
+		"warning":     "⚠",
+		"watch":       "◉",
+
+		// Rail, gauges and separators
+		"arrow":           "→",
+		"dot":             "●",
+		"hollow_dot":      "○",
+		"hline":           "─",
+		"vline":           "│",
+		"capacity_low":    "▂",
+		"capacity_medium": "▄",
+		"capacity_high":   "▆",
+		"scroll_track":    "│",
+		"scroll_thumb":    "┃",
+		"separator":       "·",
+		"marker":          "▌",
+	},
+	IconSetASCII: {
+		"arrival":     "A",
+		"board":       "B",
+		"connections": "C",
+		"departure":   "D",
+		"platform":    "#",
+		"prompt":      ">",
+		"search":      "/",
+		"swap":        "X",
+		"vehicle":     "T",
+		"walk":        "w",
+		"warning":     "!",
+		"watch":       "*",
+
+		// Rail, gauges and separators
+		"arrow":           "->",
+		"dot":             "o",
+		"hollow_dot":      ".",
+		"hline":           "-",
+		"vline":           "|",
+		"capacity_low":    ".",
+		"capacity_medium": ":",
+		"capacity_high":   "#",
+		"scroll_track":    "|",
+		"scroll_thumb":    "#",
+		"separator":       "-",
+		"marker":          ">",
+	},
+}
+
+// IconSet returns the configured icon set, guessed from the terminal when
+// left to auto.
+func (c Config) IconSet() string {
+	name := cmp.Or(c.Icons[iconSetKey], IconSetAuto)
+	if name == IconSetAuto {
+		return DetectIconSet(os.Getenv)
+	}
+	return name
+}
+
+// SetIconSet picks the icon set, keeping the icon overrides.
+func (c *Config) SetIconSet(name string) {
+	icons := map[string]string{iconSetKey: name}
+	for k, v := range c.Icons {
+		if k != iconSetKey {
+			icons[k] = v
+		}
+	}
+	c.Icons = icons
+}
+
+// IconGlyphs returns the icons of the chosen set with the overrides applied.
+func (c Config) IconGlyphs() map[string]string {
+	glyphs := maps.Clone(IconSets[c.IconSet()])
+	for name, icon := range c.Icons {
+		if name != iconSetKey {
+			glyphs[name] = icon
+		}
+	}
+	return glyphs
+}
+
+// DetectIconSet guesses what the terminal can draw. Nerd fonts cannot be
+// detected and stock fonts lack them, so they are left for the user to opt
+// into. Only ASCII is left on the Linux console or outside UTF-8.
+func DetectIconSet(getenv func(string) string) string {
+	switch getenv("TERM") {
+	case "linux", "dumb", "vt100", "vt220":
+		return IconSetASCII
+	}
+
+	locale := strings.ToLower(cmp.Or(getenv("LC_ALL"), getenv("LC_CTYPE"), getenv("LANG")))
+	if locale != "" && !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8") {
+		return IconSetASCII
+	}
+	return IconSetUnicode
+}
+
+func iconSetNames() []string {
+	names := []string{IconSetAuto}
+	for name := range IconSets {
+		names = append(names, name)
+	}
+	slices.Sort(names[1:])
+	return names
+}
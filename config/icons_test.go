@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestIconSetsCoverAllIcons(t *testing.T) {
+	for set, icons := range IconSets {
+		for _, name := range IconNames {
+			icon, ok := icons[name]
+			if !ok {
+				t.Errorf("%s has no %s icon", set, name)
+			}
+			if set == IconSetASCII && (icon == "" || !isASCII(icon)) {
+				t.Errorf("ascii %s icon %q is not ASCII", name, icon)
+			}
+		}
+	}
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDetectIconSet(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{"TERM": "xterm-256color", "LANG": "de_CH.UTF-8"}, IconSetUnicode},
+		{map[string]string{"TERM": "xterm-256color"}, IconSetUnicode},
+		{map[string]string{"TERM": "linux", "LANG": "de_CH.UTF-8"}, IconSetASCII},
+		{map[string]string{"TERM": "xterm", "LANG": "C"}, IconSetASCII},
+		{map[string]string{"TERM": "xterm", "LC_ALL": "en_US.utf8", "LANG": "C"}, IconSetUnicode},
+		{map[string]string{"TERM_PROGRAM": "Apple_Terminal", "LANG": "en_US.UTF-8"}, IconSetUnicode},
+		{map[string]string{"WT_SESSION": "1"}, IconSetUnicode},
+	}
+	for _, tt := range tests {
+		// Nerd fonts are never guessed
+		if got := DetectIconSet(func(key string) string { return tt.env[key] }); got != tt.want {
+			t.Errorf("DetectIconSet(%v) = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestIconGlyphs(t *testing.T) {
+	cfg := Default()
+	cfg.Icons = map[string]string{"walk": "W"}
+	cfg.SetIconSet(IconSetASCII)
+
+	glyphs := cfg.IconGlyphs()
+	if glyphs["walk"] != "W" || glyphs["warning"] != "!" {
+		t.Errorf("IconGlyphs() = %v, want the ascii set with walk overridden", glyphs)
+	}
+	if _, ok := glyphs["set"]; ok {
+		t.Error("IconGlyphs() holds the set key")
+	}
+
+	cfg.SetIconSet("emoji")
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted an unknown icon set")
+	}
+}
+
+func TestIconSetAuto(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	cfg := Default()
+	if got := cfg.IconSet(); got != IconSetUnicode {
+		t.Errorf("auto icon set = %q, want unicode", got)
+	}
+	cfg.SetIconSet(IconSetNerdfont)
+	if got := cfg.IconSet(); got != IconSetNerdfont {
+		t.Errorf("icon set = %q, want the nerdfont opted into", got)
+	}
+}
@@ -1,16 +1,74 @@
 package main
 
 import (
+	"cmp"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 
+	"sbb-tui/api"
+	"sbb-tui/api/fixtures"
+	"sbb-tui/cli"
+	"sbb-tui/config"
 	"sbb-tui/views"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// Set by goreleaser
+var version = "dev"
+
 func main() {
-	m := views.InitialModel()
+	// Flags up to the command apply to every command
+	flags := flag.NewFlagSet("sbb-tui", flag.ContinueOnError)
+	flags.Usage = func() {}
+	showVersion := flags.Bool("version", false, "print the version")
+	flags.BoolVar(showVersion, "v", false, "print the version")
+	icons := flags.String("icons", "", "icon set")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Print(cli.Usage)
+			os.Exit(cli.ExitOK)
+		}
+		fmt.Fprint(os.Stderr, "\n"+cli.Usage)
+		os.Exit(cli.ExitUsage)
+	}
+	args := flags.Args()
+
+	if *showVersion {
+		fmt.Println("sbb-tui", version)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid configuration:", err)
+		os.Exit(1)
+	}
+	if *icons != "" {
+		cfg.SetIconSet(*icons)
+		if err := cfg.Validate(); err != nil {
+			fmt.Fprintln(os.Stderr, "invalid --icons:", err)
+			os.Exit(cli.ExitUsage)
+		}
+	}
+
+	client := api.NewClient(cfg.API.URL)
+	client.HTTPClient.Timeout = cfg.API.Timeout
+	client.MaxRetries = cfg.API.Retries
+	client.UserAgent = cmp.Or(cfg.API.UserAgent, api.DefaultUserAgent+"/"+version)
+
+	var provider api.Provider = client
+	if cfg.API.Provider == config.ProviderFixtures {
+		provider = fixtures.New()
+	}
+
+	if len(args) > 0 {
+		os.Exit(cli.Run(args, cfg, provider, os.Stdout, os.Stderr))
+	}
+
+	m := views.InitialModel(cfg, provider)
 
 	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
 		fmt.Println("could not run program:", err)
@@ -2,6 +2,7 @@
 package models
 
 import (
+	"strconv"
 	"strings"
 	"time"
 )
@@ -21,7 +22,10 @@ func (st *SBBDateLayout) UnmarshalJSON(b []byte) error {
 	}
 	t, err := time.Parse("2006-01-02T15:04:05-0700", s)
 	if err != nil {
-		return err
+		// Our own marshalled output, e.g. from the cache
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return err
+		}
 	}
 	st.Time = t
 	return nil
@@ -37,13 +41,79 @@ type Departure struct {
 	Departure SBBDateLayout `json:"departure"`
 	Platform  string        `json:"platform"`
 	Delay     int           `json:"delay"`
+	Prognosis *Prognosis    `json:"prognosis"`
 }
 
 type Arrival struct {
-	Station  Station       `json:"station"`
-	Arrival  SBBDateLayout `json:"arrival"`
-	Platform string        `json:"platform"`
-	Delay    int           `json:"delay"`
+	Station   Station       `json:"station"`
+	Arrival   SBBDateLayout `json:"arrival"`
+	Platform  string        `json:"platform"`
+	Delay     int           `json:"delay"`
+	Prognosis *Prognosis    `json:"prognosis"`
+}
+
+// ExpectedPlatform is the platform announced in real time, falling back to
+// the scheduled one.
+func (d Departure) ExpectedPlatform() string {
+	if d.Prognosis != nil && d.Prognosis.Platform != "" {
+		return d.Prognosis.Platform
+	}
+	return d.Platform
+}
+
+func (a Arrival) ExpectedPlatform() string {
+	if a.Prognosis != nil && a.Prognosis.Platform != "" {
+		return a.Prognosis.Platform
+	}
+	return a.Platform
+}
+
+// Prognosis holds the real-time expectations when they differ from the
+// timetable.
+type Prognosis struct {
+	Platform    string        `json:"platform"`
+	Arrival     SBBDateLayout `json:"arrival"`
+	Departure   SBBDateLayout `json:"departure"`
+	Capacity1st Capacity      `json:"capacity1st"`
+	Capacity2nd Capacity      `json:"capacity2nd"`
+}
+
+// Capacity is the expected occupancy of a class.
+type Capacity int
+
+const (
+	CapacityUnknown Capacity = iota
+	CapacityLow
+	CapacityMedium
+	CapacityHigh
+)
+
+// UnmarshalJSON accepts the API's numbers, also when quoted, and folds
+// anything above high, like fully booked, into high.
+func (c *Capacity) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), "\"")
+	if s == "null" || s == "" {
+		*c = CapacityUnknown
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*c = Capacity(min(max(n, 0), int(CapacityHigh)))
+	return nil
+}
+
+// Disruption is a service message about the route, e.g. construction work.
+type Disruption struct {
+	Header string `json:"header"`
+	Lead   string `json:"lead"`
+	Text   string `json:"text"`
+}
+
+type Service struct {
+	Regular   string `json:"regular"`
+	Irregular string `json:"irregular"`
 }
 
 type Station struct {
@@ -51,14 +121,31 @@ type Station struct {
 	Coordinate Coordinate `json:"coordinate"`
 }
 
+type Stop struct {
+	Station   Station       `json:"station"`
+	Arrival   SBBDateLayout `json:"arrival"`
+	Departure SBBDateLayout `json:"departure"`
+	Delay     int           `json:"delay"`
+	Platform  string        `json:"platform"`
+	Prognosis *Prognosis    `json:"prognosis"`
+	Cancelled bool          `json:"cancelled"`
+}
+
+type Journey struct {
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Number      string   `json:"number"`
+	Operator    string   `json:"operator"`
+	To          string   `json:"to"`
+	Cancelled   bool     `json:"cancelled"`
+	Capacity1st Capacity `json:"capacity1st"`
+	Capacity2nd Capacity `json:"capacity2nd"`
+	PassList    []Stop   `json:"passList"`
+}
+
 type Section struct {
-	Journey *struct {
-		Category string `json:"category"`
-		Number   string `json:"number"`
-		Operator string `json:"operator"`
-		To       string `json:"to"`
-	} `json:"journey"`
-	Walk *struct {
+	Journey *Journey `json:"journey"`
+	Walk    *struct {
 		Duration  int       `json:"duration"`
 		Departure Departure `json:"departure"`
 		Arrival   Arrival   `json:"arrival"`
@@ -81,24 +168,59 @@ type Connection struct {
 		Platform string        `json:"platform"`
 	} `json:"to"`
 
-	Duration    string    `json:"duration"`
-	Transfers   int       `json:"transfers"`
-	Capacity1st string    `json:"capacity1st"`
-	Capacity2nd string    `json:"capacity2nd"`
-	Sections    []Section `json:"sections"`
+	Duration    string       `json:"duration"`
+	Transfers   int          `json:"transfers"`
+	Service     *Service     `json:"service"`
+	Capacity1st Capacity     `json:"capacity1st"`
+	Capacity2nd Capacity     `json:"capacity2nd"`
+	Sections    []Section    `json:"sections"`
+	Disruptions []Disruption `json:"disruptions"`
 }
 
 type Input struct {
 	From            string
 	To              string
-	Via             string
+	Via             []string
 	Date            time.Time
 	Time            time.Time
 	IsArrivalTime   bool
-	Transportations string
+	Transportations []string
 	Limit           int
+	Page            int
+}
+
+// Transportations accepted by the API's transportations[] filter
+var Transportations = []string{"train", "tram", "ship", "bus", "cableway"}
+
+// MaxVia is the number of via stations the API accepts.
+const MaxVia = 5
+
+// MaxLimit is the largest number of connections the API returns at once.
+const MaxLimit = 16
+
+type Location struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Score      *int       `json:"score"`
+	Coordinate Coordinate `json:"coordinate"`
+	Distance   *int       `json:"distance"`
+	Icon       string     `json:"icon"`
+}
+
+type StationboardEntry struct {
+	Journey
+	Stop Stop `json:"stop"`
 }
 
 type APIResponse struct {
 	Connections []Connection `json:"connections"`
 }
+
+type LocationsResponse struct {
+	Stations []Location `json:"stations"`
+}
+
+type StationboardResponse struct {
+	Station      Station             `json:"station"`
+	Stationboard []StationboardEntry `json:"stationboard"`
+}
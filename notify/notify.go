@@ -0,0 +1,51 @@
+// Package notify
+package notify
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+)
+
+// Notifier tells the user about something that happened while they were
+// not looking at the app.
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// Bell rings the terminal bell, which most terminals and tmux turn into an
+// urgency hint.
+type Bell struct {
+	W io.Writer
+}
+
+func (b Bell) Notify(_, _ string) error {
+	_, err := io.WriteString(b.W, "\a")
+	return err
+}
+
+// Desktop sends a freedesktop notification through notify-send.
+type Desktop struct {
+	AppName string
+}
+
+func (d Desktop) Notify(title, message string) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return err
+	}
+	return exec.Command(path, "--app-name="+d.AppName, title, message).Run()
+}
+
+// Multi notifies through every notifier, even when one of them fails.
+type Multi []Notifier
+
+func (m Multi) Notify(title, message string) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(title, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
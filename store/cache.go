@@ -0,0 +1,154 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sbb-tui/config"
+	"sbb-tui/models"
+)
+
+// Cache stores the connections of each search in its own file, named after
+// the hash of the normalized query.
+type Cache struct {
+	dir        string
+	ttl        time.Duration
+	maxEntries int
+}
+
+type CacheEntry struct {
+	Key         string              `json:"key"`
+	StoredAt    time.Time           `json:"storedAt"`
+	Connections []models.Connection `json:"connections"`
+}
+
+func (e CacheEntry) Age() time.Duration {
+	return time.Since(e.StoredAt)
+}
+
+func OpenCache(ttl time.Duration, maxEntries int) (*Cache, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{
+		dir:        filepath.Join(dir, "connections"),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}, nil
+}
+
+// CacheKey normalizes the query so that searches differing only in case,
+// spacing or transportation order share an entry.
+func CacheKey(input models.Input) string {
+	norm := func(s string) string {
+		return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	}
+
+	var via []string
+	for _, v := range input.Via {
+		via = append(via, norm(v))
+	}
+	transportations := slices.Clone(input.Transportations)
+	slices.Sort(transportations)
+
+	parts := []string{
+		"from=" + norm(input.From),
+		"to=" + norm(input.To),
+		"via=" + strings.Join(via, ","),
+		"date=" + formatOrEmpty(input.Date, "2006-01-02"),
+		"time=" + formatOrEmpty(input.Time, "15:04"),
+		"arrival=" + strconv.FormatBool(input.IsArrivalTime),
+		"transportations=" + strings.Join(transportations, ","),
+		"limit=" + strconv.Itoa(input.Limit),
+		"page=" + strconv.Itoa(input.Page),
+	}
+	return strings.Join(parts, "&")
+}
+
+func formatOrEmpty(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:12])+".json")
+}
+
+// Get returns the stored entry for the query, whatever its age.
+func (c *Cache) Get(input models.Input) (CacheEntry, bool) {
+	key := CacheKey(input)
+
+	var entry CacheEntry
+	if err := readJSON(c.path(key), &entry); err != nil || entry.Key != key {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Fresh reports whether the entry is young enough to be shown before the
+// refresh completes.
+func (c *Cache) Fresh(entry CacheEntry) bool {
+	return entry.Age() < c.ttl
+}
+
+func (c *Cache) Put(input models.Input, connections []models.Connection) error {
+	key := CacheKey(input)
+	entry := CacheEntry{Key: key, StoredAt: time.Now(), Connections: connections}
+	if err := writeJSON(c.path(key), entry); err != nil {
+		return err
+	}
+	return c.prune()
+}
+
+// prune removes the least recently stored entries above the size cap.
+func (c *Cache) prune() error {
+	files, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	type stored struct {
+		name    string
+		modTime time.Time
+	}
+	var entries []stored
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, stored{f.Name(), info.ModTime()})
+	}
+
+	if len(entries) <= c.maxEntries {
+		return nil
+	}
+
+	slices.SortFunc(entries, func(a, b stored) int {
+		return a.modTime.Compare(b.modTime)
+	})
+	for _, e := range entries[:len(entries)-c.maxEntries] {
+		if err := os.Remove(filepath.Join(c.dir, e.name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
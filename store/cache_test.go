@@ -0,0 +1,114 @@
+package store
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"sbb-tui/models"
+)
+
+func openCache(t *testing.T, ttl time.Duration, maxEntries int) *Cache {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	c, err := OpenCache(ttl, maxEntries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCacheKey(t *testing.T) {
+	day := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	base := models.Input{
+		From:            "Bern",
+		To:              "Zürich HB",
+		Via:             []string{"Olten"},
+		Date:            day,
+		Transportations: []string{"train", "bus"},
+		Limit:           4,
+	}
+
+	same := base
+	same.From = "  bern "
+	same.To = "ZÜRICH   hb"
+	same.Via = []string{"olten"}
+	same.Transportations = []string{"bus", "train"}
+	if CacheKey(same) != CacheKey(base) {
+		t.Errorf("keys differ for the same query:\n%s\n%s", CacheKey(same), CacheKey(base))
+	}
+
+	for name, change := range map[string]func(*models.Input){
+		"to":      func(in *models.Input) { in.To = "Basel SBB" },
+		"via":     func(in *models.Input) { in.Via = nil },
+		"date":    func(in *models.Input) { in.Date = day.AddDate(0, 0, 1) },
+		"time":    func(in *models.Input) { in.Time = day.Add(8 * time.Hour) },
+		"arrival": func(in *models.Input) { in.IsArrivalTime = true },
+		"limit":   func(in *models.Input) { in.Limit = 6 },
+		"page":    func(in *models.Input) { in.Page = 1 },
+	} {
+		other := base
+		change(&other)
+		if CacheKey(other) == CacheKey(base) {
+			t.Errorf("changing the %s keeps the key", name)
+		}
+	}
+}
+
+func TestCacheGetPut(t *testing.T) {
+	c := openCache(t, time.Minute, 10)
+	input := models.Input{From: "Bern", To: "Zürich HB"}
+
+	if _, ok := c.Get(input); ok {
+		t.Fatal("empty cache returned an entry")
+	}
+
+	connections := []models.Connection{{Duration: "00d00:56:00"}}
+	if err := c.Put(input, connections); err != nil {
+		t.Fatal(err)
+	}
+
+	input.From = "BERN"
+	entry, ok := c.Get(input)
+	if !ok || len(entry.Connections) != 1 || entry.Connections[0].Duration != "00d00:56:00" {
+		t.Fatalf("Get() = %+v, %v, want the stored connection", entry, ok)
+	}
+	if !c.Fresh(entry) {
+		t.Error("a new entry is not fresh")
+	}
+
+	entry.StoredAt = time.Now().Add(-2 * time.Minute)
+	if c.Fresh(entry) {
+		t.Error("an entry older than the TTL is fresh")
+	}
+}
+
+func TestCachePrune(t *testing.T) {
+	c := openCache(t, time.Minute, 2)
+	inputs := []models.Input{
+		{From: "Bern", To: "Zürich HB"},
+		{From: "Bern", To: "Basel SBB"},
+		{From: "Bern", To: "Luzern"},
+	}
+	connections := []models.Connection{{}}
+
+	for i, input := range inputs {
+		if err := c.Put(input, connections); err != nil {
+			t.Fatal(err)
+		}
+		// Order the entries by age, however coarse the file times are
+		stored := time.Now().Add(time.Duration(i-len(inputs)) * time.Hour)
+		if err := os.Chtimes(c.path(CacheKey(input)), stored, stored); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, ok := c.Get(inputs[0]); ok {
+		t.Error("the oldest entry was kept above the size cap")
+	}
+	for _, input := range inputs[1:] {
+		if _, ok := c.Get(input); !ok {
+			t.Errorf("entry to %s was pruned", input.To)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"slices"
+	"strings"
+)
+
+type Favorite struct {
+	Name          string   `json:"name"`
+	From          string   `json:"from"`
+	To            string   `json:"to"`
+	Via           []string `json:"via,omitempty"`
+	IsArrivalTime bool     `json:"isArrivalTime"`
+}
+
+type Favorites struct {
+	path  string
+	Items []Favorite
+}
+
+func LoadFavorites() (*Favorites, error) {
+	path, err := dataFile("favorites.json")
+	if err != nil {
+		return &Favorites{}, err
+	}
+
+	f := &Favorites{path: path}
+	writable, err := loadJSON(path, &f.Items)
+	if err != nil {
+		f.Items = nil
+	}
+	if !writable {
+		f.path = ""
+	}
+	return f, err
+}
+
+// Add stores the favorite, replacing any other with the same name.
+func (f *Favorites) Add(fav Favorite) error {
+	f.Items = slices.DeleteFunc(f.Items, func(item Favorite) bool {
+		return strings.EqualFold(item.Name, fav.Name)
+	})
+	f.Items = append(f.Items, fav)
+	return f.save()
+}
+
+func (f *Favorites) Remove(name string) error {
+	f.Items = slices.DeleteFunc(f.Items, func(item Favorite) bool {
+		return item.Name == name
+	})
+	return f.save()
+}
+
+func (f *Favorites) save() error {
+	if f.path == "" {
+		return nil
+	}
+	return writeJSON(f.path, f.Items)
+}
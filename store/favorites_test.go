@@ -0,0 +1,97 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// dataDir points the data directory at a temporary one and returns it.
+func dataDir(t *testing.T) string {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dir, err := dataFile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestFavoritesRoundTrip(t *testing.T) {
+	dataDir(t)
+
+	favs, err := LoadFavorites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fav := range []Favorite{
+		{Name: "Work", From: "Bern", To: "Zürich HB"},
+		{Name: "Home", From: "Zürich HB", To: "Bern"},
+		{Name: "work", From: "Bern", To: "Basel SBB"},
+	} {
+		if err := favs.Add(fav); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := favs.Remove("Home"); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadFavorites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Items) != 1 || loaded.Items[0].To != "Basel SBB" {
+		t.Errorf("loaded %+v, want only the renamed work favorite", loaded.Items)
+	}
+}
+
+func TestCorruptFavoritesAreKept(t *testing.T) {
+	dir := dataDir(t)
+	path := filepath.Join(dir, "favorites.json")
+	corrupt := []byte(`[{"name": "Work", "from": "Bern"`)
+	if err := os.WriteFile(path, corrupt, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	favs, err := LoadFavorites()
+	if err == nil {
+		t.Fatal("loaded a corrupt file without an error")
+	}
+	if len(favs.Items) != 0 {
+		t.Errorf("loaded %+v from a corrupt file", favs.Items)
+	}
+
+	if err := favs.Add(Favorite{Name: "Home", From: "Zürich HB", To: "Bern"}); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(path + ".bad"); err != nil || string(b) != string(corrupt) {
+		t.Errorf("corrupt file was not kept aside: %q, %v", b, err)
+	}
+	if loaded, err := LoadFavorites(); err != nil || len(loaded.Items) != 1 {
+		t.Errorf("new file holds %+v, %v, want the new favorite", loaded.Items, err)
+	}
+}
+
+func TestUnreadableFavoritesAreNotOverwritten(t *testing.T) {
+	dir := dataDir(t)
+	// A directory in place of the file fails to read
+	path := filepath.Join(dir, "favorites.json")
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	favs, err := LoadFavorites()
+	if err == nil {
+		t.Fatal("loaded an unreadable file without an error")
+	}
+	if err := favs.Add(Favorite{Name: "Home", From: "Zürich HB", To: "Bern"}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		t.Error("unreadable file was replaced")
+	}
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"sbb-tui/utils"
+)
+
+const maxHistory = 500
+
+type HistoryEntry struct {
+	From          string    `json:"from"`
+	To            string    `json:"to"`
+	Date          string    `json:"date,omitempty"`
+	Time          string    `json:"time,omitempty"`
+	IsArrivalTime bool      `json:"isArrivalTime"`
+	SearchedAt    time.Time `json:"searchedAt"`
+}
+
+func (e HistoryEntry) String() string {
+	s := e.From + " → " + e.To
+	if e.Date != "" {
+		s += " " + e.Date
+	}
+	if e.Time != "" {
+		s += " " + e.Time
+	}
+	return s
+}
+
+func (e HistoryEntry) sameQuery(other HistoryEntry) bool {
+	return strings.EqualFold(e.From, other.From) &&
+		strings.EqualFold(e.To, other.To) &&
+		e.Date == other.Date &&
+		e.Time == other.Time &&
+		e.IsArrivalTime == other.IsArrivalTime
+}
+
+// History keeps past searches, oldest first.
+type History struct {
+	path    string
+	Entries []HistoryEntry
+}
+
+func LoadHistory() (*History, error) {
+	path, err := dataFile("history.json")
+	if err != nil {
+		return &History{}, err
+	}
+
+	h := &History{path: path}
+	writable, err := loadJSON(path, &h.Entries)
+	if err != nil {
+		h.Entries = nil
+	}
+	if !writable {
+		h.path = ""
+	}
+	return h, err
+}
+
+// Add records a search, moving a repeated query to the end instead of
+// storing it twice.
+func (h *History) Add(e HistoryEntry) error {
+	h.Entries = slices.DeleteFunc(h.Entries, e.sameQuery)
+	h.Entries = append(h.Entries, e)
+	if len(h.Entries) > maxHistory {
+		h.Entries = h.Entries[len(h.Entries)-maxHistory:]
+	}
+
+	if h.path == "" {
+		return nil
+	}
+	return writeJSON(h.path, h.Entries)
+}
+
+// Search returns the entries fuzzy matching query, best and most recent
+// first.
+func (h *History) Search(query string) []HistoryEntry {
+	type match struct {
+		entry HistoryEntry
+		score int
+		order int
+	}
+
+	var matches []match
+	for i, e := range h.Entries {
+		if score, ok := utils.FuzzyScore(query, e.String()); ok {
+			matches = append(matches, match{e, score, i})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+		return b.order - a.order
+	})
+
+	entries := make([]HistoryEntry, len(matches))
+	for i, m := range matches {
+		entries[i] = m.entry
+	}
+	return entries
+}
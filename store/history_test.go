@@ -0,0 +1,109 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func entry(from, to string) HistoryEntry {
+	return HistoryEntry{From: from, To: to}
+}
+
+func TestHistorySearch(t *testing.T) {
+	h := &History{Entries: []HistoryEntry{
+		entry("Bern", "Zürich HB"),
+		entry("Basel SBB", "Genève"),
+		entry("Zürich HB", "Bern"),
+		entry("Regalia", "Bern"),
+		entry("St. Gallen", "Bern"),
+		entry("Luzern", "Bern"),
+	}}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// Equal scores list the most recent search first, the b of HB
+		// scatters the last match
+		{"bern", []string{
+			"Luzern → Bern", "St. Gallen → Bern", "Regalia → Bern",
+			"Bern → Zürich HB", "Zürich HB → Bern",
+		}},
+		// Accents fold either way
+		{"zur", []string{"Zürich HB → Bern", "Bern → Zürich HB"}},
+		{"geneve", []string{"Basel SBB → Genève"}},
+		// A match at a word start ranks above a more recent one inside a word
+		{"gal", []string{"St. Gallen → Bern", "Regalia → Bern"}},
+		{"olten", nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, e := range h.Search(tt.query) {
+			got = append(got, e.String())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestHistoryAdd(t *testing.T) {
+	dataDir(t)
+
+	h, err := LoadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	searches := []HistoryEntry{
+		entry("Bern", "Zürich HB"),
+		entry("Basel SBB", "Bern"),
+		entry("bern", "zürich hb"),
+	}
+	for _, e := range searches {
+		e.SearchedAt = time.Now()
+		if err := h.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded, err := LoadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range loaded.Entries {
+		got = append(got, e.String())
+	}
+	// The repeated query moves to the end
+	if want := []string{"Basel SBB → Bern", "bern → zürich hb"}; !slices.Equal(got, want) {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+}
+
+func TestCorruptHistoryIsKept(t *testing.T) {
+	dir := dataDir(t)
+	path := filepath.Join(dir, "history.json")
+	corrupt := []byte(`[{"from": "Bern", "to": 3}]`)
+	if err := os.WriteFile(path, corrupt, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := LoadHistory()
+	if err == nil {
+		t.Fatal("loaded a corrupt file without an error")
+	}
+	if len(h.Entries) != 0 {
+		t.Errorf("loaded %+v from a corrupt file", h.Entries)
+	}
+
+	if err := h.Add(entry("Bern", "Olten")); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(path + ".bad"); err != nil || string(b) != string(corrupt) {
+		t.Errorf("corrupt file was not kept aside: %q, %v", b, err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package store
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"sbb-tui/config"
+)
+
+func dataFile(name string) (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// readJSON decodes the file into v, leaving v untouched if it does not exist.
+func readJSON(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// loadJSON reads a data file of the user into v. A file that does not decode
+// is moved aside to name.bad, so that the next save starts over instead of
+// overwriting it. It returns false when the file is still in place unread
+// and must not be saved over.
+func loadJSON(path string, v any) (bool, error) {
+	err := readJSON(path, v)
+	if err == nil {
+		return true, nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr) {
+		return false, err
+	}
+
+	bad := path + ".bad"
+	if renameErr := os.Rename(path, bad); renameErr != nil {
+		return false, err
+	}
+	return true, fmt.Errorf("%w, moved it to %s", err, bad)
+}
+
+// writeJSON replaces the file atomically so a crash never leaves it half written.
+func writeJSON(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
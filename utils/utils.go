@@ -1,7 +1,11 @@
 // Package utils
 package utils
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 func Btoi(b bool) int {
 	if b {
@@ -13,3 +17,69 @@ func Btoi(b bool) int {
 func RenderLink(text, url string) string {
 	return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", url, text)
 }
+
+// 00d01:15:00" -> "1h 15m" or "15 min".
+func FormatDuration(duration string) string {
+	parts := strings.Split(duration, ":")
+	if len(parts) < 2 {
+		return duration
+	}
+
+	minutes := parts[1]
+	if len(parts[0]) > 3 && parts[0][3:] != "00" {
+		hours := parts[0][3:]
+		return hours + "h " + minutes + "m"
+	}
+	return minutes + "min"
+}
+
+// accentFolder maps the accented letters of Swiss station names to their
+// base letter, so "zur" finds "Zürich".
+var accentFolder = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i",
+	"ô", "o", "ö", "o",
+	"ù", "u", "û", "u", "ü", "u",
+)
+
+// FuzzyScore matches pattern as a case and accent insensitive subsequence of
+// s. Higher scores reward consecutive runs and matches at word starts.
+func FuzzyScore(pattern, s string) (int, bool) {
+	p := []rune(accentFolder.Replace(strings.ToLower(pattern)))
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	score, pi, run := 0, 0, 0
+	prev := ' '
+	for _, r := range accentFolder.Replace(strings.ToLower(s)) {
+		if pi < len(p) && r == p[pi] {
+			run++
+			score += run
+			if prev == ' ' || prev == '-' || prev == ',' {
+				score += 3
+			}
+			pi++
+		} else {
+			run = 0
+		}
+		prev = r
+	}
+
+	return score, pi == len(p)
+}
+
+// FormatAge tells how long ago something happened, e.g. "5 min ago".
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%d min ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d days ago", int(d.Hours()/24))
+}
@@ -0,0 +1,48 @@
+package utils
+
+import "testing"
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		score      int
+		ok         bool
+	}{
+		{"", "Bern", 0, true},
+		{"bern", "Bern", 3 + 1 + 2 + 3 + 4, true},
+		{"BERN", "bern", 3 + 1 + 2 + 3 + 4, true},
+		{"zur", "Zürich HB", 3 + 1 + 2 + 3, true},
+		{"zür", "Zurich HB", 3 + 1 + 2 + 3, true},
+		{"genève", "Geneve", 3 + 1 + 2 + 3 + 4 + 5 + 6, true},
+		// Letters match as early as possible, here the h of Zürich
+		{"zhb", "Zürich HB", 3 + 1 + 1 + 1, true},
+		{"ern", "Bern", 1 + 2 + 3, true},
+		{"bz", "Bern → Zürich HB", 3 + 1 + 3 + 1, true},
+		{"zb", "Bern → Zürich HB", 3 + 1 + 1, true},
+		{"nb", "Bern → Zürich", 0, false},
+		{"basel", "Bern", 0, false},
+	}
+
+	for _, tt := range tests {
+		score, ok := FuzzyScore(tt.pattern, tt.s)
+		if ok != tt.ok || (ok && score != tt.score) {
+			t.Errorf("FuzzyScore(%q, %q) = %d, %v, want %d, %v", tt.pattern, tt.s, score, ok, tt.score, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyScoreRanking(t *testing.T) {
+	// A match at word starts beats one inside a word
+	start, _ := FuzzyScore("gal", "St. Gallen")
+	inside, _ := FuzzyScore("gal", "Regalia")
+	if start <= inside {
+		t.Errorf("word start scored %d, not above %d inside a word", start, inside)
+	}
+
+	// A consecutive run beats scattered letters
+	run, _ := FuzzyScore("ber", "Bern")
+	scattered, _ := FuzzyScore("ber", "Basel Erlen Rheinfelden")
+	if run <= scattered {
+		t.Errorf("run scored %d, not above %d for scattered letters", run, scattered)
+	}
+}
@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// When is a date and time typed by the user, either part may be missing.
+type When struct {
+	Date    time.Time
+	Time    time.Time
+	HasDate bool
+	HasTime bool
+}
+
+var (
+	relativePattern = regexp.MustCompile(`^(?:\+|in\s*)(\d+)\s*(m|min|mins|minutes?|h|hrs?|hours?|d|days?)$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?h?$`)
+	compactClock    = regexp.MustCompile(`^(\d{2})(\d{2})$`)
+	swissPattern    = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.?(\d{2}|\d{4})?$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+var errWhen = errors.New("unrecognized date or time")
+
+// ParseWhen understands "now", "today", "tomorrow", weekdays like "fri",
+// relative offsets like "+45m" or "in 2h", clock times like "7:30" and
+// dates as "2006-01-02" or Swiss "16.10.", combined as "tomorrow 7:30".
+func ParseWhen(s string, now time.Time) (When, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return When{}, nil
+	}
+
+	if s == "now" || s == "jetzt" {
+		return When{Date: day(now), Time: now, HasDate: true, HasTime: true}, nil
+	}
+
+	if m := relativePattern.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2][0] {
+		case 'd':
+			return When{Date: day(now).AddDate(0, 0, n), HasDate: true}, nil
+		case 'h':
+			t := now.Add(time.Duration(n) * time.Hour)
+			return When{Date: day(t), Time: t, HasDate: true, HasTime: true}, nil
+		default:
+			t := now.Add(time.Duration(n) * time.Minute)
+			return When{Date: day(t), Time: t, HasDate: true, HasTime: true}, nil
+		}
+	}
+
+	var w When
+	for _, field := range strings.Fields(s) {
+		if date, ok := parseDate(field, now); ok && !w.HasDate {
+			w.Date, w.HasDate = date, true
+			continue
+		}
+		if clock, ok := parseClock(field, now); ok && !w.HasTime {
+			w.Time, w.HasTime = clock, true
+			continue
+		}
+		return When{}, fmt.Errorf("%w: %q", errWhen, field)
+	}
+
+	// Place the clock on the chosen day
+	if w.HasDate && w.HasTime {
+		w.Time = time.Date(w.Date.Year(), w.Date.Month(), w.Date.Day(),
+			w.Time.Hour(), w.Time.Minute(), 0, 0, now.Location())
+	}
+	return w, nil
+}
+
+// ParseDate reads a date field, which may carry a time along with the day
+// but not a time alone: "12" or "2025" are no dates.
+func ParseDate(s string, now time.Time) (When, error) {
+	w, err := ParseWhen(s, now)
+	if err == nil && w.HasTime && !w.HasDate {
+		return When{}, fmt.Errorf("%w: %q is a time, not a date", errWhen, strings.TrimSpace(s))
+	}
+	return w, err
+}
+
+// Merge combines the date field with the time field, each field having the
+// last word on its own part.
+func (w When) Merge(timeField When) When {
+	merged := w
+	if timeField.HasTime {
+		merged.Time, merged.HasTime = timeField.Time, true
+	}
+	if !merged.HasDate && timeField.HasDate {
+		merged.Date, merged.HasDate = timeField.Date, true
+	}
+	return merged
+}
+
+// String renders the resolved value, e.g. "Fri 17.10.2026 18:00".
+func (w When) String() string {
+	var parts []string
+	if w.HasDate {
+		parts = append(parts, w.Date.Format("Mon 02.01.2006"))
+	}
+	if w.HasTime {
+		parts = append(parts, w.Time.Format("15:04"))
+	}
+	return strings.Join(parts, " ")
+}
+
+func parseDate(s string, now time.Time) (time.Time, bool) {
+	today := day(now)
+
+	switch s {
+	case "today", "heute":
+		return today, true
+	case "tomorrow", "tmrw", "morgen":
+		return today.AddDate(0, 0, 1), true
+	}
+
+	if wd, ok := weekdayName(s); ok {
+		return today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7), true
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, true
+	}
+
+	if m := swissPattern.FindStringSubmatch(s); m != nil {
+		d, _ := strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[2])
+		y := now.Year()
+		if m[3] != "" {
+			y, _ = strconv.Atoi(m[3])
+			if len(m[3]) == 2 {
+				y += 2000
+			}
+		}
+		t := time.Date(y, time.Month(mo), d, 0, 0, 0, 0, now.Location())
+		// Reject what time.Date would normalize, like 31.02.
+		if t.Day() != d || int(t.Month()) != mo {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+
+	return time.Time{}, false
+}
+
+func weekdayName(s string) (time.Weekday, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	wd, ok := weekdays[s[:3]]
+	if !ok {
+		return 0, false
+	}
+	// Accept "fri", "friday", but not "frisbee"
+	full := strings.ToLower(wd.String())
+	return wd, strings.HasPrefix(full, s)
+}
+
+func parseClock(s string, now time.Time) (time.Time, bool) {
+	m := clockPattern.FindStringSubmatch(s)
+	if m == nil {
+		m = compactClock.FindStringSubmatch(s)
+	}
+	if m == nil {
+		return time.Time{}, false
+	}
+
+	h, _ := strconv.Atoi(m[1])
+	mi := 0
+	if m[2] != "" {
+		mi, _ = strconv.Atoi(m[2])
+	}
+	if h > 23 || mi > 59 {
+		return time.Time{}, false
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), h, mi, 0, 0, now.Location()), true
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// TimetablePeriod returns the first and last day of the yearly timetable
+// running on t. The timetable changes on the Sunday following the second
+// Saturday of December.
+func TimetablePeriod(t time.Time) (time.Time, time.Time) {
+	start := timetableChange(t.Year(), t.Location())
+	if day(t).Before(start) {
+		start = timetableChange(t.Year()-1, t.Location())
+	}
+	end := timetableChange(start.Year()+1, t.Location()).AddDate(0, 0, -1)
+	return start, end
+}
+
+func timetableChange(year int, loc *time.Location) time.Time {
+	first := time.Date(year, time.December, 1, 0, 0, 0, 0, loc)
+	firstSaturday := 1 + (int(time.Saturday)-int(first.Weekday())+7)%7
+	return time.Date(year, time.December, firstSaturday+8, 0, 0, 0, 0, loc)
+}
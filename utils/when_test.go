@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// A Friday morning
+var whenNow = time.Date(2026, time.October, 16, 10, 0, 0, 0, time.FixedZone("CEST", 7200))
+
+// formatWhen renders the parts of w that are set, empty for the others.
+func formatWhen(w When) (string, string) {
+	var date, clock string
+	if w.HasDate {
+		date = w.Date.Format("2006-01-02")
+	}
+	if w.HasTime {
+		clock = w.Time.Format("2006-01-02 15:04")
+	}
+	return date, clock
+}
+
+func TestParseWhen(t *testing.T) {
+	tests := []struct {
+		in    string
+		date  string
+		clock string
+		err   bool
+	}{
+		{in: ""},
+		{in: "  "},
+		{in: "now", date: "2026-10-16", clock: "2026-10-16 10:00"},
+		{in: "Jetzt", date: "2026-10-16", clock: "2026-10-16 10:00"},
+		{in: "today", date: "2026-10-16"},
+		{in: "heute", date: "2026-10-16"},
+		{in: "TOMORROW", date: "2026-10-17"},
+		{in: "morgen", date: "2026-10-17"},
+		{in: "tmrw", date: "2026-10-17"},
+
+		// Weekdays, today included
+		{in: "fri", date: "2026-10-16"},
+		{in: "friday", date: "2026-10-16"},
+		{in: "mon", date: "2026-10-19"},
+		{in: "thursday", date: "2026-10-22"},
+		{in: "frisbee", err: true},
+		{in: "fr", err: true},
+
+		// Relative offsets
+		{in: "+45m", date: "2026-10-16", clock: "2026-10-16 10:45"},
+		{in: "in 90 min", date: "2026-10-16", clock: "2026-10-16 11:30"},
+		{in: "in2h", date: "2026-10-16", clock: "2026-10-16 12:00"},
+		{in: "+15 hours", date: "2026-10-17", clock: "2026-10-17 01:00"},
+		{in: "+2d", date: "2026-10-18"},
+		{in: "in 1 day", date: "2026-10-17"},
+		{in: "+2w", err: true},
+
+		// Clock times
+		{in: "7:30", clock: "2026-10-16 07:30"},
+		{in: "0730", clock: "2026-10-16 07:30"},
+		{in: "18h", clock: "2026-10-16 18:00"},
+		{in: "12", clock: "2026-10-16 12:00"},
+		{in: "2025", clock: "2026-10-16 20:25"},
+		{in: "24:00", err: true},
+		{in: "12:60", err: true},
+		{in: "730", err: true},
+
+		// Dates
+		{in: "2026-12-24", date: "2026-12-24"},
+		{in: "2026-02-30", err: true},
+		{in: "24.12.", date: "2026-12-24"},
+		{in: "24.12", date: "2026-12-24"},
+		{in: "1.1.27", date: "2027-01-01"},
+		{in: "16.10.2026", date: "2026-10-16"},
+		{in: "31.02.", err: true},
+		{in: "29.2.2028", date: "2028-02-29"},
+		{in: "29.2.2027", err: true},
+		{in: "0.10.", err: true},
+
+		// Combined, the clock placed on the day
+		{in: "tomorrow 7:30", date: "2026-10-17", clock: "2026-10-17 07:30"},
+		{in: "7:30 tomorrow", date: "2026-10-17", clock: "2026-10-17 07:30"},
+		{in: "24.12. 18h", date: "2026-12-24", clock: "2026-12-24 18:00"},
+		{in: "fri fri", err: true},
+		{in: "7:30 8:30", err: true},
+		{in: "tomorrow soon", err: true},
+	}
+
+	for _, tt := range tests {
+		w, err := ParseWhen(tt.in, whenNow)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseWhen(%q) = %+v, want an error", tt.in, w)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseWhen(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if date, clock := formatWhen(w); date != tt.date || clock != tt.clock {
+			t.Errorf("ParseWhen(%q) = %q, %q, want %q, %q", tt.in, date, clock, tt.date, tt.clock)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	for _, in := range []string{"12", "2025", "7:30", "18h"} {
+		if w, err := ParseDate(in, whenNow); err == nil {
+			t.Errorf("ParseDate(%q) = %+v, want an error", in, w)
+		}
+	}
+	for _, in := range []string{"", "today", "16.10.", "tomorrow 7:30", "+45m", "now"} {
+		if _, err := ParseDate(in, whenNow); err != nil {
+			t.Errorf("ParseDate(%q) failed: %v", in, err)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		date, clock string
+		want        string
+	}{
+		{"tomorrow", "7:30", "Sat 17.10.2026 07:30"},
+		{"tomorrow 7:30", "", "Sat 17.10.2026 07:30"},
+		{"tomorrow 7:30", "9:00", "Sat 17.10.2026 09:00"},
+		{"", "+45m", "Fri 16.10.2026 10:45"},
+		{"mon", "+45m", "Mon 19.10.2026 10:45"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		date, err := ParseWhen(tt.date, whenNow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clock, err := ParseWhen(tt.clock, whenNow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := date.Merge(clock).String(); got != tt.want {
+			t.Errorf("%q merged with %q = %q, want %q", tt.date, tt.clock, got, tt.want)
+		}
+	}
+}
+
+func TestTimetablePeriod(t *testing.T) {
+	tests := []struct {
+		day        string
+		start, end string
+	}{
+		{"2026-10-16", "2025-12-14", "2026-12-12"},
+		{"2026-12-12", "2025-12-14", "2026-12-12"},
+		{"2026-12-13", "2026-12-13", "2027-12-11"},
+		{"2025-01-01", "2024-12-15", "2025-12-13"},
+	}
+
+	for _, tt := range tests {
+		day, _ := time.Parse("2006-01-02", tt.day)
+		start, end := TimetablePeriod(day)
+		if got := start.Format("2006-01-02"); got != tt.start {
+			t.Errorf("TimetablePeriod(%s) starts %s, want %s", tt.day, got, tt.start)
+		}
+		if got := end.Format("2006-01-02"); got != tt.end {
+			t.Errorf("TimetablePeriod(%s) ends %s, want %s", tt.day, got, tt.end)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sbb-tui/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// Departure board layout
+	boardTitleHeight = 2
+	boardBadgeCol    = 10
+)
+
+type BoardMsg struct {
+	id      int
+	entries []models.StationboardEntry
+	err     error
+}
+
+func (m *model) toggleMode() {
+	if m.mode == ModeBoard {
+		m.mode = ModeConnections
+		m.headerOrder = connectionsHeader()
+		m.inputs[0].Placeholder = "From"
+	} else {
+		m.mode = ModeBoard
+		m.headerOrder = boardHeader()
+		m.inputs[0].Placeholder = "Station"
+	}
+
+	// Keep the focus on the mode button, which leads both headers
+	m.tabIndex = 0
+	m.abortSearch()
+	m.cachedAt = time.Time{}
+	m.offline = false
+	m.changes = nil
+	m.connections = nil
+	m.board = nil
+	m.resultIndex = 0
+	m.resultOffset = 0
+	m.errorMsg = ""
+	m.fieldErrors = nil
+	m.detailOpen = false
+	m.searched = false
+	m.clearSuggestions()
+	m.resizeInputs()
+}
+
+func (m model) maxVisibleBoardEntries() int {
+	return max(m.resultsHeight()-boardTitleHeight, 1)
+}
+
+func (m model) boardCmd() tea.Cmd {
+	provider, ctx, id, input := m.provider, m.searchCtx, m.searchID, m.query
+	return func() tea.Msg {
+		res, err := provider.Stationboard(ctx, input)
+		return BoardMsg{id: id, entries: res, err: err}
+	}
+}
+
+func (m model) renderBoard() string {
+	if m.loading {
+		return "\n  Loading departure board... " + m.cancelHint()
+	}
+
+	if m.errorMsg != "" {
+		return "\n  " + noStyle.Foreground(sbbRed).Render(m.errorMsg)
+	}
+
+	if len(m.board) == 0 {
+		if m.searched {
+			return "\n  No departures found."
+		}
+		return "\n  Enter a station above to see its departure board"
+	}
+
+	const timeCol = 5
+	const delayCol = 4
+	const symbolCol = boardBadgeCol + 4
+
+	width := m.contentWidth() - rsltMrgn*2
+
+	// Label the board by the query that produced it, not by the switch,
+	// which may have been toggled since
+	arrivals := m.query.IsArrivalTime
+
+	station := m.board[0].Stop.Station.Name
+	title := "Departures from " + station
+	if arrivals {
+		title = "Arrivals at " + station
+	}
+
+	lines := []string{noStyle.Bold(true).Render(title), ""}
+
+	// A limit above what fits leaves the last row to count the rest
+	entries := m.board
+	if visible := m.maxVisibleBoardEntries(); len(entries) > visible {
+		entries = entries[:visible-1]
+	}
+
+	for _, e := range entries {
+		t := e.Stop.Departure
+		if arrivals && !e.Stop.Arrival.IsZero() {
+			t = e.Stop.Arrival
+		}
+
+		// Arrivals are labelled by their origin, departures by their destination
+		target := e.To
+		if arrivals && len(e.PassList) > 0 {
+			target = e.PassList[0].Station.Name
+		}
+
+		badge := vehicleCategoryStyle.Width(boardBadgeCol).
+			Render(truncateString(strings.TrimSpace(e.Category+" "+e.Number), boardBadgeCol))
+
+		lines = append(lines, m.formatStationLine(
+			t.Local().Format("15:04"),
+			e.Stop.Delay,
+			badge,
+			arrow+" "+target,
+			e.Stop.Platform,
+			width, timeCol, delayCol, symbolCol, false, stopChange{},
+		))
+	}
+
+	if hidden := len(m.board) - len(entries); hidden > 0 {
+		lines = append(lines, noStyle.Foreground(sbbGray).Render(fmt.Sprintf("... %d more", hidden)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
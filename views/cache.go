@@ -0,0 +1,103 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"sbb-tui/api"
+	"sbb-tui/models"
+	"sbb-tui/utils"
+)
+
+// showCached fills the results with the cached connections of the current
+// query. Unless anyAge is set, only entries younger than the TTL qualify.
+func (m *model) showCached(anyAge bool) bool {
+	if m.cache == nil || m.mode != ModeConnections {
+		return false
+	}
+
+	entry, ok := m.cache.Get(m.query)
+	if !ok || len(entry.Connections) == 0 || (!anyAge && !m.cache.Fresh(entry)) {
+		return false
+	}
+
+	m.hiddenCrowded = 0
+	m.connections = m.filterCrowded(entry.Connections)
+	m.resultIndex = 0
+	m.resultOffset = 0
+	m.cachedAt = entry.StoredAt
+	return true
+}
+
+// keepCached falls back to cached results when the API cannot be reached,
+// a rejected query stays an error.
+func (m *model) keepCached(err error) bool {
+	var badRequest *api.BadRequestError
+	if errors.As(err, &badRequest) {
+		return false
+	}
+
+	if m.cachedAt.IsZero() && !m.showCached(true) {
+		return false
+	}
+	m.offline = true
+	return true
+}
+
+func (m *model) cacheResults(connections []models.Connection) {
+	if m.cache == nil || len(connections) == 0 {
+		return
+	}
+	// The cache is best effort, a failed write only costs the next lookup
+	_ = m.cache.Put(m.query, connections)
+}
+
+// replaceConnections swaps in refreshed results, keeping the selection on
+// the same journey when it is still listed.
+func (m *model) replaceConnections(connections []models.Connection) {
+	selected := ""
+	if m.resultIndex < len(m.connections) {
+		selected = connectionKey(m.connections[m.resultIndex])
+	}
+
+	m.connections = connections
+	m.resultIndex = 0
+	for i, c := range connections {
+		if connectionKey(c) == selected {
+			m.resultIndex = i
+			break
+		}
+	}
+	m.scrollResults()
+}
+
+func (m model) cacheStatus() string {
+	if m.cachedAt.IsZero() {
+		return ""
+	}
+
+	age := utils.FormatAge(time.Since(m.cachedAt))
+	switch {
+	case m.refreshing:
+		return "Cached " + age + ", refreshing... " + m.cancelHint()
+	case m.offline:
+		return "Offline, showing results cached " + age + "."
+	}
+	return "Cached " + age + "."
+}
+
+// statusLine is shown below the results list.
+func (m model) statusLine() string {
+	if m.pageStatus != "" {
+		return m.pageStatus
+	}
+
+	var parts []string
+	for _, s := range []string{m.cacheStatus(), m.crowdedStatus(), m.watchStatus(), m.detailHint()} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " "+separator+" ")
+}
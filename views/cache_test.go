@@ -0,0 +1,85 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"sbb-tui/api"
+	"sbb-tui/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var errUnreachable = &api.NetworkError{Err: errors.New("connection refused")}
+
+// newCachedModel searches from Bern to Zürich once, leaving its results in
+// the cache, and types the same search again without sending it.
+func newCachedModel(t *testing.T, ttl time.Duration) model {
+	t.Helper()
+	m := newTestModel(t, 160, 40, func(cfg *config.Config) {
+		cfg.Cache.Enabled = true
+		cfg.Cache.TTL = ttl
+	})
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Zürich")
+	m = press(t, m, tea.KeyEnter)
+	if len(m.connections) != 4 {
+		t.Fatalf("got %d connections, want 4 (error %q)", len(m.connections), m.errorMsg)
+	}
+	return m
+}
+
+func TestCachedResultsWhileRefreshing(t *testing.T) {
+	m := newCachedModel(t, time.Minute)
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.loading || !m.refreshing || len(m.connections) != 4 {
+		t.Fatalf("loading %v, refreshing %v with %d connections, want the cached ones refreshing",
+			m.loading, m.refreshing, len(m.connections))
+	}
+	if view := m.View(); !strings.Contains(view, "Cached just now, refreshing...") {
+		t.Errorf("view is missing the cache status:\n%s", view)
+	}
+
+	// The refresh fails, the cached results stay as offline ones
+	m = send(t, m, DataMsg{id: m.searchID, err: errUnreachable})
+	if m.errorMsg != "" || len(m.connections) != 4 || !m.offline {
+		t.Errorf("error %q with %d connections, offline %v, want the cached ones offline",
+			m.errorMsg, len(m.connections), m.offline)
+	}
+	view := m.View()
+	for _, want := range []string{"Offline, showing results cached just now.", "St. Gallen"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestStaleCacheOnlyWhenOffline(t *testing.T) {
+	// Nothing is fresh without a TTL
+	m := newCachedModel(t, 0)
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.loading || len(m.connections) != 0 {
+		t.Fatalf("loading %v with %d connections, want a stale entry kept back", m.loading, len(m.connections))
+	}
+
+	m = send(t, m, DataMsg{id: m.searchID, err: errUnreachable})
+	if m.errorMsg != "" || len(m.connections) != 4 || !m.offline {
+		t.Errorf("error %q with %d connections, offline %v, want the stale ones offline",
+			m.errorMsg, len(m.connections), m.offline)
+	}
+	if view := m.View(); !strings.Contains(view, "Offline, showing results cached") {
+		t.Errorf("view is missing the offline status:\n%s", view)
+	}
+
+	// A rejected query is no reason to fall back
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = send(t, m, DataMsg{id: m.searchID, err: &api.BadRequestError{StatusCode: 400}})
+	if m.errorMsg == "" || len(m.connections) != 0 {
+		t.Errorf("error %q with %d connections, want the rejection shown", m.errorMsg, len(m.connections))
+	}
+}
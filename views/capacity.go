@@ -0,0 +1,107 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"sbb-tui/models"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Occupancy bars, one per level
+var capacityBars = []string{"▂", "▄", "▆"}
+
+// sectionCapacity prefers the journey's own figures over the prognosis at
+// its departure.
+func sectionCapacity(s models.Section) (first, second models.Capacity) {
+	if s.Journey != nil {
+		first, second = s.Journey.Capacity1st, s.Journey.Capacity2nd
+	}
+	if p := s.Departure.Prognosis; p != nil {
+		first = max(first, p.Capacity1st)
+		second = max(second, p.Capacity2nd)
+	}
+	return first, second
+}
+
+// connectionCapacity falls back to the busiest section when the connection
+// has no overall figure.
+func connectionCapacity(c models.Connection) (first, second models.Capacity) {
+	first, second = c.Capacity1st, c.Capacity2nd
+	for _, s := range c.Sections {
+		f, sc := sectionCapacity(s)
+		if c.Capacity1st == models.CapacityUnknown {
+			first = max(first, f)
+		}
+		if c.Capacity2nd == models.CapacityUnknown {
+			second = max(second, sc)
+		}
+	}
+	return first, second
+}
+
+func isCrowded(c models.Connection) bool {
+	_, second := connectionCapacity(c)
+	return second == models.CapacityHigh
+}
+
+// filterCrowded drops the connections with a high 2nd class load when the
+// filter is on, counting what it hid.
+func (m *model) filterCrowded(connections []models.Connection) []models.Connection {
+	if !m.hideCrowded {
+		return connections
+	}
+
+	var kept []models.Connection
+	for _, c := range connections {
+		if isCrowded(c) {
+			m.hiddenCrowded++
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+func renderCapacity(label string, c models.Capacity) string {
+	if c == models.CapacityUnknown {
+		return ""
+	}
+
+	color := sbbWhite
+	if c == models.CapacityHigh {
+		color = sbbRed
+	}
+
+	// The level shows in the number of bars, not only in their color, and
+	// blanks keep the gauges aligned
+	var sb strings.Builder
+	for i, bar := range capacityBars {
+		if i < int(c) {
+			sb.WriteString(noStyle.Foreground(color).Render(bar))
+		} else {
+			sb.WriteString(strings.Repeat(" ", lipgloss.Width(bar)))
+		}
+	}
+	return label + " " + sb.String()
+}
+
+// renderCapacities shows both classes, empty when nothing is known.
+func renderCapacities(first, second models.Capacity) string {
+	var parts []string
+	if s := renderCapacity("1.", first); s != "" {
+		parts = append(parts, s)
+	}
+	if s := renderCapacity("2.", second); s != "" {
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (m model) crowdedStatus() string {
+	if m.hiddenCrowded == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d crowded hidden", m.hiddenCrowded)
+}
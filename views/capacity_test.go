@@ -0,0 +1,30 @@
+package views
+
+import (
+	"testing"
+
+	"sbb-tui/models"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestCapacityReadsWithoutColor(t *testing.T) {
+	want := map[models.Capacity]string{
+		models.CapacityLow:    "2. ▂  ",
+		models.CapacityMedium: "2. ▂▄ ",
+		models.CapacityHigh:   "2. ▂▄▆",
+	}
+	for c, bars := range want {
+		got := renderCapacity("2.", c)
+		if plain := ansi.Strip(got); plain != bars {
+			t.Errorf("capacity %d renders %q without color, want %q", c, plain, bars)
+		}
+		if w := lipgloss.Width(got); w != lipgloss.Width(bars) {
+			t.Errorf("capacity %d is %d cells wide, want %d", c, w, lipgloss.Width(bars))
+		}
+	}
+	if got := renderCapacity("2.", models.CapacityUnknown); got != "" {
+		t.Errorf("unknown capacity renders %q", got)
+	}
+}
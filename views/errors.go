@@ -0,0 +1,34 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+
+	"sbb-tui/api"
+)
+
+// fetchErrorMessage explains a failed API request, what names the data
+// that could not be loaded.
+func fetchErrorMessage(err error, what string) string {
+	var (
+		network     *api.NetworkError
+		rateLimited *api.RateLimitedError
+		server      *api.ServerError
+		badRequest  *api.BadRequestError
+	)
+
+	switch {
+	case errors.As(err, &network):
+		return fmt.Sprintf("Failed to fetch %s. Check your internet connection.", what)
+	case errors.As(err, &rateLimited):
+		return fmt.Sprintf("Too many requests, the timetable API refused to send %s. Try again in a moment.", what)
+	case errors.As(err, &server):
+		return fmt.Sprintf("The timetable API is unavailable (%d), could not fetch %s.", server.StatusCode, what)
+	case errors.As(err, &badRequest):
+		if badRequest.Message != "" {
+			return fmt.Sprintf("The timetable API rejected the request: %s", badRequest.Message)
+		}
+		return fmt.Sprintf("The timetable API rejected the request for %s.", what)
+	}
+	return fmt.Sprintf("Failed to fetch %s: %v", what, err)
+}
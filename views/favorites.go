@@ -0,0 +1,175 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"sbb-tui/store"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// Favorites overlay
+	favBoxWidth   = 56
+	favMaxVisible = 10
+)
+
+func (m *model) openFavoriteNaming() tea.Cmd {
+	from := strings.TrimSpace(m.inputs[0].Value())
+	to := strings.TrimSpace(m.inputs[1].Value())
+	if m.mode != ModeConnections || from == "" || to == "" {
+		return m.setNotice("Enter a departure and an arrival station to save a favorite.")
+	}
+
+	t := textinput.New()
+	t.Prompt = "Name: "
+	t.CharLimit = 40
+	t.Width = favBoxWidth - 10
+	t.SetValue(from + " " + arrow + " " + to)
+	t.CursorEnd()
+	m.favName = t
+	m.favNaming = true
+	return m.favName.Focus()
+}
+
+func (m *model) saveFavorite() tea.Cmd {
+	name := strings.TrimSpace(m.favName.Value())
+	if name == "" {
+		return nil
+	}
+
+	input := m.searchInput()
+	err := m.favorites.Add(store.Favorite{
+		Name:          name,
+		From:          input.From,
+		To:            input.To,
+		Via:           input.Via,
+		IsArrivalTime: m.isArrivalTime,
+	})
+	m.favNaming = false
+	if err != nil {
+		return m.setNotice("Could not save the favorite: " + err.Error())
+	}
+	return nil
+}
+
+// applyFavorite fills the header with the favorite and searches right away.
+func (m *model) applyFavorite(fav store.Favorite) tea.Cmd {
+	if m.mode != ModeConnections {
+		m.toggleMode()
+	}
+
+	m.inputs[0].SetValue(fav.From)
+	m.inputs[1].SetValue(fav.To)
+	m.inputs[4].SetValue(strings.Join(fav.Via, ", "))
+	m.isArrivalTime = fav.IsArrivalTime
+	m.favPicker = false
+	return m.startSearch()
+}
+
+// updateFavorites handles the naming prompt and the picker. The returned
+// bool reports whether the key was consumed.
+func (m *model) updateFavorites(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.favNaming {
+		switch {
+		case key.Matches(msg, m.keys.Search):
+			return true, m.saveFavorite()
+		case key.Matches(msg, m.keys.Close):
+			m.favNaming = false
+		default:
+			var cmd tea.Cmd
+			m.favName, cmd = m.favName.Update(msg)
+			return true, cmd
+		}
+		return true, nil
+	}
+
+	if !m.favPicker {
+		switch {
+		case key.Matches(msg, m.keys.SaveFavorite):
+			return true, m.openFavoriteNaming()
+		case key.Matches(msg, m.keys.Favorites):
+			m.favPicker = true
+			m.favIndex = 0
+			return true, nil
+		}
+		return false, nil
+	}
+
+	items := m.favorites.Items
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.favIndex > 0 {
+			m.favIndex--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.favIndex < len(items)-1 {
+			m.favIndex++
+		}
+	case key.Matches(msg, m.keys.Search):
+		if len(items) > 0 {
+			return true, m.applyFavorite(items[m.favIndex])
+		}
+	case key.Matches(msg, m.keys.Delete):
+		if len(items) > 0 {
+			err := m.favorites.Remove(items[m.favIndex].Name)
+			m.favIndex = max(min(m.favIndex, len(m.favorites.Items)-1), 0)
+			if err != nil {
+				return true, m.setNotice("Could not delete the favorite: " + err.Error())
+			}
+		}
+	case key.Matches(msg, m.keys.Close, m.keys.Favorites):
+		m.favPicker = false
+	case key.Matches(msg, m.keys.Quit):
+		return false, nil
+	}
+	return true, nil
+}
+
+func (m model) renderFavoriteNaming() string {
+	return suggestionStyle.Width(favBoxWidth).Render(
+		noStyle.Bold(true).Render("Save favorite") + "\n\n" + m.favName.View(),
+	)
+}
+
+func (m model) renderFavorites() string {
+	width := favBoxWidth - suggestionStyle.GetHorizontalFrameSize()
+	lines := []string{noStyle.Bold(true).Render("Favorites"), ""}
+
+	if len(m.favorites.Items) == 0 {
+		lines = append(lines, noStyle.Foreground(sbbGray).Render("No favorites yet, save one with "+firstKey(m.keys.SaveFavorite)))
+	}
+
+	start := max(min(m.favIndex-favMaxVisible+1, len(m.favorites.Items)-favMaxVisible), 0)
+	for i := start; i < min(start+favMaxVisible, len(m.favorites.Items)); i++ {
+		fav := m.favorites.Items[i]
+		route := fav.From + " " + arrow + " " + fav.To
+		if len(fav.Via) > 0 {
+			route += " via " + strings.Join(fav.Via, ", ")
+		}
+		mode := dptIcon
+		if fav.IsArrivalTime {
+			mode = arrIcon
+		}
+
+		line := fmt.Sprintf("%s %s  %s", mode, fav.Name, route)
+		style := noStyle.Width(width)
+		if i == m.favIndex {
+			style = style.Background(sbbRed).Foreground(sbbWhite).Bold(true)
+		}
+		lines = append(lines, style.Render(truncateString(line, width)))
+	}
+
+	return suggestionStyle.Width(favBoxWidth).Render(strings.Join(lines, "\n"))
+}
+
+// centerOverlay draws box on top of the view, centered horizontally just
+// below the header.
+func (m model) centerOverlay(box, view string) string {
+	x := max((m.width-lipgloss.Width(box))/2, 0)
+	return placeOverlay(x, m.headerHeight()+1, box, view)
+}
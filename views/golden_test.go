@@ -0,0 +1,202 @@
+package views
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"sbb-tui/api/fixtures"
+	"sbb-tui/config"
+	"sbb-tui/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files")
+
+func TestMain(m *testing.M) {
+	// Render the same colors and clock times on every machine
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	time.Local = time.FixedZone("CEST", 2*60*60)
+	os.Exit(m.Run())
+}
+
+// golden compares got with testdata/<name>.golden once stripped of ANSI
+// sequences, and with testdata/<name>.ansi.golden as rendered. Run the
+// tests with -update to accept the new output.
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	variants := map[string]string{
+		name + ".golden":      ansi.Strip(got),
+		name + ".ansi.golden": got,
+	}
+
+	for file, got := range variants {
+		path := filepath.Join("testdata", file)
+		if *updateGolden {
+			if err := os.MkdirAll("testdata", 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("%v, run the tests with -update to create it", err)
+		}
+		if got != string(want) {
+			t.Errorf("%s does not match, run the tests with -update if the change is intended\ngot:\n%s\nwant:\n%s",
+				path, ansi.Strip(got), ansi.Strip(string(want)))
+		}
+	}
+}
+
+// goldenScreen compares the whole view, which has to fill the terminal
+// exactly.
+func goldenScreen(t *testing.T, name string, m model) {
+	t.Helper()
+	view := m.View()
+	if got := lipgloss.Height(view); got != m.height {
+		t.Errorf("%s: view is %d lines high, want %d", name, got, m.height)
+	}
+	if got := lipgloss.Width(view); got > m.width {
+		t.Errorf("%s: view is %d cells wide, want at most %d", name, got, m.width)
+	}
+	golden(t, name, view)
+}
+
+func fixtureConnections(t *testing.T) []models.Connection {
+	t.Helper()
+	connections, err := fixtures.New().Connections(context.Background(), models.Input{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return connections
+}
+
+// newGoldenModel shows connections as if searched from Bern to Zürich HB,
+// with the header holding fixed values instead of the current time.
+func newGoldenModel(t *testing.T, connections []models.Connection, width, height int, options ...func(*config.Config)) model {
+	t.Helper()
+	m := newTestModel(t, width, height, options...)
+	m.inputs[0].SetValue("Bern")
+	m.inputs[1].SetValue("Zürich HB")
+	m.inputs[2].SetValue("2026-10-16")
+	m.inputs[3].SetValue("08:00")
+	m.connections = connections
+	m.searched = true
+
+	next, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return next.(model)
+}
+
+func TestGoldenView(t *testing.T) {
+	sizes := []struct{ width, height int }{{160, 40}, {120, 30}}
+	for _, size := range sizes {
+		for _, selected := range []int{0, 1} {
+			m := newGoldenModel(t, fixtureConnections(t), size.width, size.height)
+			m.resultIndex = selected
+			goldenScreen(t, fmt.Sprintf("view_%dx%d_%d", size.width, size.height, selected), m)
+		}
+	}
+}
+
+func TestGoldenIconSets(t *testing.T) {
+	for _, set := range []string{config.IconSetUnicode, config.IconSetASCII} {
+		m := newGoldenModel(t, fixtureConnections(t), 160, 40, func(cfg *config.Config) {
+			cfg.SetIconSet(set)
+		})
+		goldenScreen(t, "icons_"+set, m)
+	}
+
+	// Only the station names may leave ASCII
+	m := newGoldenModel(t, fixtureConnections(t), 160, 40, func(cfg *config.Config) {
+		cfg.SetIconSet(config.IconSetASCII)
+	})
+	for _, r := range ansi.Strip(m.View()) {
+		if r >= utf8.RuneSelf && !unicode.IsLetter(r) {
+			t.Errorf("ascii view holds %q", r)
+		}
+	}
+}
+
+// TestGoldenLayouts covers the breakpoints: wrapped header, stacked panes,
+// compact list and the minimum size notice.
+func TestGoldenLayouts(t *testing.T) {
+	sizes := []struct{ width, height int }{{90, 30}, {160, 16}, {80, 14}, {50, 10}}
+	for _, size := range sizes {
+		m := newGoldenModel(t, fixtureConnections(t), size.width, size.height)
+		m.resultIndex = 1
+		goldenScreen(t, fmt.Sprintf("layout_%dx%d", size.width, size.height), m)
+	}
+
+	// Enter opens the detail of the selected connection when stacked
+	m := newGoldenModel(t, fixtureConnections(t), 90, 30)
+	m.query = m.searchInput()
+	m = press(t, m, tea.KeyDown, tea.KeyEnter)
+	if !m.detailOpen {
+		t.Fatal("enter did not open the detail")
+	}
+	goldenScreen(t, "layout_90x30_detail", m)
+
+	if m = press(t, m, tea.KeyEsc); m.detailOpen {
+		t.Error("esc did not close the detail")
+	}
+}
+
+func TestGoldenBoard(t *testing.T) {
+	m := newTestModel(t, 120, 30)
+	m.toggleMode()
+	m.inputs[0].SetValue("Bern")
+	m.inputs[2].SetValue("2026-10-16")
+	m.inputs[3].SetValue("08:00")
+
+	board, err := fixtures.New().Stationboard(context.Background(), models.Input{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.board = board
+	m.searched = true
+	goldenScreen(t, "board_120x30", m)
+}
+
+func TestGoldenSimpleConnection(t *testing.T) {
+	connections := fixtureConnections(t)
+	m := newGoldenModel(t, connections, 160, 40)
+	for i, c := range connections {
+		golden(t, fmt.Sprintf("simple_%d", i), m.renderSimpleConnection(c, i, m.resultBoxWidth()))
+	}
+}
+
+func TestGoldenFullConnection(t *testing.T) {
+	connections := fixtureConnections(t)
+	m := newGoldenModel(t, connections, 160, 40)
+	for i, c := range connections {
+		golden(t, fmt.Sprintf("full_%d", i), m.renderFullConnection(c, connectionChange{}, 80))
+	}
+
+	// The intermediate stops of the first train
+	m.resultIndex = 1
+	m.toggleStops()
+	golden(t, "full_1_stops", m.renderFullConnection(connections[1], connectionChange{}, 80))
+}
+
+func TestGoldenStopsLine(t *testing.T) {
+	for i, c := range fixtureConnections(t) {
+		for _, width := range []int{20, 40} {
+			golden(t, fmt.Sprintf("stops_line_%d_%d", i, width), renderStopsLine(c, width))
+		}
+	}
+}
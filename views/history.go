@@ -0,0 +1,129 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	"sbb-tui/store"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const histMaxVisible = 10
+
+// recordSearch adds the last query to the history once it returned results.
+func (m *model) recordSearch() tea.Cmd {
+	if m.mode != ModeConnections {
+		return nil
+	}
+
+	entry := store.HistoryEntry{
+		From:          m.query.From,
+		To:            m.query.To,
+		IsArrivalTime: m.query.IsArrivalTime,
+		SearchedAt:    time.Now(),
+	}
+	if !m.query.Date.IsZero() {
+		entry.Date = m.query.Date.Format("2006-01-02")
+	}
+	if !m.query.Time.IsZero() {
+		entry.Time = m.query.Time.Format("15:04")
+	}
+
+	if err := m.history.Add(entry); err != nil {
+		return m.setNotice("Could not save the search history: " + err.Error())
+	}
+	return nil
+}
+
+func (m *model) openHistory() tea.Cmd {
+	t := textinput.New()
+	t.Prompt = "(reverse-i-search) "
+	t.Placeholder = "station, date or time"
+	t.Width = favBoxWidth - 24
+	m.histQuery = t
+	m.histMatches = m.history.Search("")
+	m.histIndex = 0
+	m.histSearch = true
+	return m.histQuery.Focus()
+}
+
+// applyHistory fills the header with a past search without running it.
+func (m *model) applyHistory(e store.HistoryEntry) {
+	if m.mode != ModeConnections {
+		m.toggleMode()
+	}
+
+	m.inputs[0].SetValue(e.From)
+	m.inputs[1].SetValue(e.To)
+	m.inputs[2].SetValue(e.Date)
+	m.inputs[3].SetValue(e.Time)
+	m.isArrivalTime = e.IsArrivalTime
+	m.histSearch = false
+}
+
+// updateHistory handles the reverse search overlay. The returned bool
+// reports whether the key was consumed.
+func (m *model) updateHistory(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if !m.histSearch {
+		if key.Matches(msg, m.keys.History) {
+			return true, m.openHistory()
+		}
+		return false, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.histIndex > 0 {
+			m.histIndex--
+		}
+	case key.Matches(msg, m.keys.Down, m.keys.History):
+		if m.histIndex < len(m.histMatches)-1 {
+			m.histIndex++
+		}
+	case key.Matches(msg, m.keys.Search):
+		if len(m.histMatches) > 0 {
+			m.applyHistory(m.histMatches[m.histIndex])
+		}
+	case key.Matches(msg, m.keys.Close):
+		m.histSearch = false
+	case key.Matches(msg, m.keys.Quit):
+		return false, nil
+	default:
+		var cmd tea.Cmd
+		m.histQuery, cmd = m.histQuery.Update(msg)
+		m.histMatches = m.history.Search(m.histQuery.Value())
+		m.histIndex = 0
+		return true, cmd
+	}
+	return true, nil
+}
+
+func (m model) renderHistory() string {
+	width := favBoxWidth - suggestionStyle.GetHorizontalFrameSize()
+	lines := []string{m.histQuery.View(), ""}
+
+	if len(m.histMatches) == 0 {
+		lines = append(lines, noStyle.Foreground(sbbGray).Render("No matching searches"))
+	}
+
+	start := max(min(m.histIndex-histMaxVisible+1, len(m.histMatches)-histMaxVisible), 0)
+	for i := start; i < min(start+histMaxVisible, len(m.histMatches)); i++ {
+		e := m.histMatches[i]
+		mode := dptIcon
+		if e.IsArrivalTime {
+			mode = arrIcon
+		}
+
+		line := mode + " " + e.String()
+		style := noStyle.Width(width)
+		if i == m.histIndex {
+			style = style.Background(sbbRed).Foreground(sbbWhite).Bold(true)
+		}
+		lines = append(lines, style.Render(truncateString(line, width)))
+	}
+
+	return suggestionStyle.Width(favBoxWidth).Render(strings.Join(lines, "\n"))
+}
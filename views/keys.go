@@ -0,0 +1,111 @@
+package views
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	Quit     key.Binding
+	SoftQuit key.Binding
+	Close    key.Binding
+	Cancel   key.Binding
+	Search   key.Binding
+	Toggle   key.Binding
+	Next     key.Binding
+	Prev     key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Home     key.Binding
+	End      key.Binding
+
+	SaveFavorite key.Binding
+	Favorites    key.Binding
+	Delete       key.Binding
+	History      key.Binding
+	Watch        key.Binding
+	Stops        key.Binding
+	HideCrowded  key.Binding
+	Picker       key.Binding
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Quit:     key.NewBinding(key.WithKeys("ctrl+c", "esc")),
+		SoftQuit: key.NewBinding(key.WithKeys("q")),
+		Close:    key.NewBinding(key.WithKeys("esc")),
+		Cancel:   key.NewBinding(key.WithKeys("ctrl+x", "esc")),
+		Search:   key.NewBinding(key.WithKeys("enter")),
+		Toggle:   key.NewBinding(key.WithKeys(" ")),
+		Next:     key.NewBinding(key.WithKeys("tab")),
+		Prev:     key.NewBinding(key.WithKeys("shift+tab")),
+		Up:       key.NewBinding(key.WithKeys("up")),
+		Down:     key.NewBinding(key.WithKeys("down")),
+		Left:     key.NewBinding(key.WithKeys("left")),
+		Right:    key.NewBinding(key.WithKeys("right")),
+		PageUp:   key.NewBinding(key.WithKeys("pgup")),
+		PageDown: key.NewBinding(key.WithKeys("pgdown")),
+		Home:     key.NewBinding(key.WithKeys("home")),
+		End:      key.NewBinding(key.WithKeys("end")),
+
+		SaveFavorite: key.NewBinding(key.WithKeys("ctrl+s")),
+		Favorites:    key.NewBinding(key.WithKeys("ctrl+f")),
+		Delete:       key.NewBinding(key.WithKeys("ctrl+d")),
+		History:      key.NewBinding(key.WithKeys("ctrl+r")),
+		Watch:        key.NewBinding(key.WithKeys("ctrl+t")),
+		Stops:        key.NewBinding(key.WithKeys("ctrl+o")),
+		HideCrowded:  key.NewBinding(key.WithKeys("ctrl+g")),
+		Picker:       key.NewBinding(key.WithKeys("ctrl+p")),
+	}
+}
+
+// newKeyMap applies the configured bindings, keyed by action name, on top of
+// the defaults.
+func newKeyMap(overrides map[string][]string) keyMap {
+	km := defaultKeyMap()
+	bindings := map[string]*key.Binding{
+		"quit":      &km.Quit,
+		"soft_quit": &km.SoftQuit,
+		"close":     &km.Close,
+		"cancel":    &km.Cancel,
+		"search":    &km.Search,
+		"toggle":    &km.Toggle,
+		"next":      &km.Next,
+		"prev":      &km.Prev,
+		"up":        &km.Up,
+		"down":      &km.Down,
+		"left":      &km.Left,
+		"right":     &km.Right,
+		"page_up":   &km.PageUp,
+		"page_down": &km.PageDown,
+		"home":      &km.Home,
+		"end":       &km.End,
+
+		"save_favorite": &km.SaveFavorite,
+		"favorites":     &km.Favorites,
+		"delete":        &km.Delete,
+		"history":       &km.History,
+		"watch":         &km.Watch,
+		"stops":         &km.Stops,
+		"hide_crowded":  &km.HideCrowded,
+		"picker":        &km.Picker,
+	}
+
+	for action, keys := range overrides {
+		if b, ok := bindings[action]; ok {
+			b.SetKeys(keys...)
+		}
+	}
+	return km
+}
+
+// firstKey names the binding in hints, empty when it has been unbound.
+func firstKey(b key.Binding) string {
+	if keys := b.Keys(); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
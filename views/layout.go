@@ -0,0 +1,165 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"sbb-tui/models"
+	"sbb-tui/store"
+	"sbb-tui/utils"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// Layout breakpoints
+	minWidth         = 60
+	minHeight        = 14
+	hdrMinInputWidth = 12
+	hdrWrapWidth     = hdrMinWidth + hdrElmtPadd + hdrMinInputWidth*3
+	stackedWidth     = 100
+	minConnBoxes     = 2
+)
+
+// tooSmall reports whether the terminal cannot fit the interface at all.
+func (m model) tooSmall() bool {
+	return m.width < minWidth || m.height < minHeight
+}
+
+// headerWrapped moves the date, time and options to a second header row
+// when the station inputs would get too narrow.
+func (m model) headerWrapped() bool {
+	return m.width < hdrWrapWidth
+}
+
+// stacked shows either the list or the detail of a connection instead of
+// both side by side.
+func (m model) stacked() bool {
+	return m.width < stackedWidth
+}
+
+// compact lists connections one per line when not even two boxes fit.
+func (m model) compact() bool {
+	return m.resultsHeight() < smplConnHeight*minConnBoxes
+}
+
+func (m model) headerHeight() int {
+	return hdrHeight * len(m.headerRows())
+}
+
+func (m model) connHeight() int {
+	if m.compact() {
+		return 1
+	}
+	return smplConnHeight
+}
+
+// headerRows splits the header items into rows, the second one starting
+// after the last station input.
+func (m model) headerRows() [][]int {
+	all := make([]int, len(m.headerOrder))
+	for i := range all {
+		all[i] = i
+	}
+	if !m.headerWrapped() {
+		return [][]int{all}
+	}
+
+	split := 0
+	for i, item := range m.headerOrder {
+		if item.id == "from" || item.id == "to" || item.id == "via" || item.id == "station" {
+			split = i + 1
+		}
+	}
+	return [][]int{all[:split], all[split:]}
+}
+
+// headerAnchor returns where an overlay belonging to a header item goes:
+// its left edge and the line below its row.
+func (m model) headerAnchor(id string) (x, y int) {
+	for r, row := range m.headerRows() {
+		x = 0
+		for _, i := range row {
+			if m.headerOrder[i].id == id {
+				return x, (r + 1) * hdrHeight
+			}
+			x += lipgloss.Width(m.renderHeaderItem(i))
+		}
+	}
+	return x, hdrHeight
+}
+
+// showDetail reports whether Enter should open the detail of the selected
+// connection rather than search again, which is the case in the stacked
+// layout as long as the header still holds the listed search.
+func (m model) showDetail() bool {
+	if !m.stacked() || m.detailOpen || m.mode != ModeConnections || len(m.connections) == 0 {
+		return false
+	}
+	input := m.searchInput()
+	input.Limit = m.query.Limit
+	return store.CacheKey(input) == store.CacheKey(m.query)
+}
+
+func (m model) detailHint() string {
+	if !m.stacked() || m.detailOpen || len(m.connections) == 0 {
+		return ""
+	}
+	return firstKey(m.keys.Search) + " for details"
+}
+
+func (m model) renderTooSmall() string {
+	msg := lipgloss.JoinVertical(lipgloss.Center,
+		noStyle.Foreground(sbbRed).Bold(true).Render("Terminal too small"),
+		noStyle.Foreground(sbbGray).Render(fmt.Sprintf("%dx%d, needs at least %dx%d", m.width, m.height, minWidth, minHeight)),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, msg)
+}
+
+// renderCompactConnection fits a connection on a single line, cutting the
+// vehicle and destination first.
+func (m model) renderCompactConnection(c models.Connection, index int, width int) string {
+	var journey *models.Journey
+	var section models.Section
+	for _, s := range c.Sections {
+		if s.Journey != nil {
+			journey, section = s.Journey, s
+			break
+		}
+	}
+
+	marker := " "
+	if index == m.resultIndex {
+		marker = noStyle.Foreground(sbbRed).Render(selMarker)
+	}
+
+	left := fmt.Sprintf("%s%s%s  %s  %s%s",
+		marker,
+		noStyle.Bold(true).Render(c.FromData.Departure.Local().Format("15:04")),
+		formatDelay(section.Departure.Delay, false),
+		renderStopsLine(c, 6),
+		noStyle.Bold(true).Render(c.ToData.Arrival.Local().Format("15:04")),
+		formatDelay(section.Arrival.Delay, false),
+	)
+
+	vehicle := ""
+	if journey != nil {
+		vehicle = vehicleCategoryStyle.Render(strings.TrimSpace(journey.Category+" "+journey.Number)) + " " + arrow + " " + journey.To
+	}
+	if m.isWatched(c) {
+		vehicle += " " + noStyle.Foreground(sbbRed).Render(wtchIcon)
+	}
+	if badge := renderWarningBadge(c, connectionWarnings(c)); badge != "" {
+		vehicle += " " + badge
+	}
+
+	right := utils.FormatDuration(c.Duration)
+	if platform := departurePlatform(c); platform != "" {
+		right += "  " + pltIcon + " " + platform
+	}
+
+	vehicle = truncateString(vehicle, width-lipgloss.Width(left)-lipgloss.Width(right)-4)
+	padding := max(width-lipgloss.Width(left)-lipgloss.Width(vehicle)-lipgloss.Width(right)-3, 1)
+	line := left + "  " + vehicle + strings.Repeat(" ", padding) + right
+	return noStyle.Width(width).Render(truncateString(line, width))
+}
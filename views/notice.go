@@ -0,0 +1,37 @@
+package views
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How long a notice stays at the bottom of the results
+const noticeDuration = 5 * time.Second
+
+type noticeTickMsg struct {
+	id int
+}
+
+// setNotice reports something that should not take the place of the
+// results, like a file that could not be written.
+func (m *model) setNotice(text string) tea.Cmd {
+	m.notice = text
+	m.noticeID++
+	id := m.noticeID
+	return tea.Tick(noticeDuration, func(time.Time) tea.Msg {
+		return noticeTickMsg{id: id}
+	})
+}
+
+func (m *model) clearNotice(msg noticeTickMsg) {
+	if msg.id == m.noticeID {
+		m.notice = ""
+	}
+}
+
+// renderNotice covers the last line of the results box.
+func (m model) renderNotice() string {
+	width := m.contentWidth() - rsltMrgn*2
+	return noStyle.Foreground(sbbRed).Width(width).Render(truncateString(m.notice, width))
+}
@@ -0,0 +1,31 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// placeOverlay draws fg on top of bg with its top-left corner at (x, y).
+func placeOverlay(x, y int, fg, bg string) string {
+	fgLines := strings.Split(fg, "\n")
+	bgLines := strings.Split(bg, "\n")
+
+	for i, fgLine := range fgLines {
+		row := y + i
+		if row < 0 || row >= len(bgLines) {
+			continue
+		}
+
+		bgLine := bgLines[row]
+		left := ansi.Truncate(bgLine, x, "")
+		if w := ansi.StringWidth(left); w < x {
+			left += strings.Repeat(" ", x-w)
+		}
+		right := ansi.TruncateLeft(bgLine, x+ansi.StringWidth(fgLine), "")
+
+		bgLines[row] = left + ansi.ResetStyle + fgLine + ansi.ResetStyle + right
+	}
+
+	return strings.Join(bgLines, "\n")
+}
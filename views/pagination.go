@@ -0,0 +1,139 @@
+package views
+
+import (
+	"fmt"
+
+	"sbb-tui/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type PageMsg struct {
+	id          int
+	page        int
+	connections []models.Connection
+	err         error
+}
+
+// fetchPage loads the given result page of the last search. Negative pages
+// hold earlier connections.
+func (m *model) fetchPage(page int) tea.Cmd {
+	if m.paging || m.loading || m.refreshing || m.mode != ModeConnections {
+		return nil
+	}
+
+	// An empty page ended the results in that direction
+	if page < m.pageFirst && m.noEarlier {
+		m.pageStatus = "No earlier connections."
+		return nil
+	}
+	if page > m.pageLast && m.noLater {
+		m.pageStatus = "No later connections."
+		return nil
+	}
+
+	m.paging = true
+	m.pageStatus = "Loading later connections..."
+	if page < m.pageFirst {
+		m.pageStatus = "Loading earlier connections..."
+	}
+
+	provider, ctx, id, input := m.provider, m.searchCtx, m.searchID, m.query
+	input.Page = page
+	return func() tea.Msg {
+		res, err := provider.Connections(ctx, input)
+		return PageMsg{id: id, page: page, connections: res, err: err}
+	}
+}
+
+// mergePage adds a fetched page before or after the current results, skipping
+// connections already listed. The selection steps onto the new connections
+// only when it still sits on the edge that asked for them, and otherwise
+// stays on its connection.
+func (m *model) mergePage(msg PageMsg) {
+	if msg.id != m.searchID {
+		return
+	}
+	m.paging = false
+	m.pageStatus = ""
+
+	if msg.err != nil {
+		m.pageStatus = fetchErrorMessage(msg.err, "more connections")
+		return
+	}
+
+	seen := make(map[string]bool, len(m.connections))
+	for _, c := range m.connections {
+		seen[connectionKey(c)] = true
+	}
+
+	var fresh []models.Connection
+	for _, c := range msg.connections {
+		if key := connectionKey(c); !seen[key] {
+			seen[key] = true
+			fresh = append(fresh, c)
+		}
+	}
+
+	hidden := m.hiddenCrowded
+	fresh = m.filterCrowded(fresh)
+	hidden = m.hiddenCrowded - hidden
+
+	if msg.page < m.pageFirst {
+		if len(msg.connections) == 0 {
+			m.noEarlier = true
+		} else {
+			m.pageFirst = msg.page
+		}
+		if len(fresh) == 0 {
+			m.pageStatus = emptyPageStatus("earlier", hidden)
+			return
+		}
+		first := m.resultIndex == 0
+		m.connections = append(fresh, m.connections...)
+		m.resultIndex += len(fresh)
+		m.resultOffset += len(fresh)
+		if first {
+			// Land on the connection right before the previous first one
+			m.moveResult(-1)
+		}
+		m.scrollResults()
+		return
+	}
+
+	if len(msg.connections) == 0 {
+		m.noLater = true
+	} else {
+		m.pageLast = msg.page
+	}
+	if len(fresh) == 0 {
+		m.pageStatus = emptyPageStatus("later", hidden)
+		return
+	}
+	last := m.resultIndex == len(m.connections)-1
+	m.connections = append(m.connections, fresh...)
+	if last {
+		m.moveResult(1)
+	}
+}
+
+// emptyPageStatus tells apart a page without connections from one whose
+// connections were all crowded.
+func emptyPageStatus(direction string, hidden int) string {
+	if hidden > 0 {
+		return fmt.Sprintf("Only crowded %s connections, %d hidden.", direction, hidden)
+	}
+	return "No " + direction + " connections."
+}
+
+// connectionKey identifies a connection across separately fetched pages.
+func connectionKey(c models.Connection) string {
+	key := c.FromData.Station.Name + "|" + c.ToData.Station.Name + "|" +
+		c.FromData.Departure.Format("2006-01-02T15:04") + "|" + c.ToData.Arrival.Format("2006-01-02T15:04")
+	for _, s := range c.Sections {
+		if s.Journey != nil {
+			key += "|" + s.Journey.Category + s.Journey.Number
+		}
+	}
+	return key
+}
@@ -0,0 +1,95 @@
+package views
+
+import (
+	"testing"
+
+	"sbb-tui/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selected returns the key of the selected connection.
+func selected(m model) string {
+	return connectionKey(m.connections[m.resultIndex])
+}
+
+func TestLaterPageSkipsKnownConnections(t *testing.T) {
+	all := fixtureConnections(t)
+	m := newGoldenModel(t, all[:2], 160, 40)
+	m.resultIndex = 1
+
+	m.mergePage(PageMsg{id: m.searchID, page: 1, connections: all[1:]})
+
+	if len(m.connections) != len(all) {
+		t.Fatalf("got %d connections, want %d", len(m.connections), len(all))
+	}
+	if selected(m) != connectionKey(all[2]) {
+		t.Error("selection did not step onto the first later connection")
+	}
+	if m.pageLast != 1 {
+		t.Errorf("last page = %d, want 1", m.pageLast)
+	}
+}
+
+func TestEarlierPageKeepsSelection(t *testing.T) {
+	all := fixtureConnections(t)
+	tests := []struct {
+		name  string
+		index int
+		want  models.Connection
+	}{
+		// Still on the first connection, which asked for the earlier page
+		{"first", 0, all[1]},
+		// Moved on while the page was loading
+		{"moved", 1, all[3]},
+	}
+
+	for _, tt := range tests {
+		m := newGoldenModel(t, all[2:], 160, 40)
+		m.resultIndex = tt.index
+
+		m.mergePage(PageMsg{id: m.searchID, page: -1, connections: all[:3]})
+
+		if len(m.connections) != len(all) {
+			t.Fatalf("%s: got %d connections, want %d", tt.name, len(m.connections), len(all))
+		}
+		if selected(m) != connectionKey(tt.want) {
+			t.Errorf("%s: selection = %d, not on the expected connection", tt.name, m.resultIndex)
+		}
+		if start, end := m.resultWindow(); m.resultIndex < start || m.resultIndex >= end {
+			t.Errorf("%s: selection %d is out of view [%d, %d)", tt.name, m.resultIndex, start, end)
+		}
+		if m.pageFirst != -1 {
+			t.Errorf("%s: first page = %d, want -1", tt.name, m.pageFirst)
+		}
+	}
+}
+
+func TestEmptyPageStopsPaging(t *testing.T) {
+	m := newGoldenModel(t, fixtureConnections(t)[:2], 160, 40)
+	m.resultIndex = 1
+
+	m.mergePage(PageMsg{id: m.searchID, page: 1})
+	if want := "No later connections."; m.pageStatus != want {
+		t.Errorf("page status = %q, want %q", m.pageStatus, want)
+	}
+
+	for range 3 {
+		next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = next.(model)
+		if cmd != nil || m.paging {
+			t.Fatal("asked for another later page after an empty one")
+		}
+	}
+	if m.pageLast != 0 {
+		t.Errorf("last page = %d, want it to stay 0", m.pageLast)
+	}
+	if want := "No later connections."; m.pageStatus != want {
+		t.Errorf("page status = %q, want %q", m.pageStatus, want)
+	}
+
+	// Earlier pages still load
+	if m.fetchPage(m.pageFirst-1) == nil {
+		t.Error("an empty later page stopped the earlier pages")
+	}
+}
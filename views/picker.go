@@ -0,0 +1,190 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sbb-tui/utils"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// Date and time pickers
+	pickerNone = iota
+	pickerDate
+	pickerTime
+)
+
+const (
+	pickerStep    = 5 * time.Minute
+	pickerRows    = 5
+	calendarWidth = 20
+)
+
+// openPicker shows the picker of the focused date or time field, starting
+// from the value it currently resolves to.
+func (m *model) openPicker() {
+	now := time.Now()
+	when, _ := m.when(now)
+
+	switch m.headerOrder[m.tabIndex].id {
+	case "date":
+		m.picker = pickerDate
+		m.pickerValue = now
+		if when.HasDate {
+			m.pickerValue = when.Date
+		}
+	case "time":
+		m.picker = pickerTime
+		m.pickerValue = now.Truncate(pickerStep).Add(pickerStep)
+		if when.HasTime {
+			m.pickerValue = when.Time.Truncate(pickerStep)
+		}
+	}
+}
+
+// updatePicker navigates the open picker. The returned bool reports whether
+// the key was consumed.
+func (m *model) updatePicker(msg tea.KeyMsg) bool {
+	if m.picker == pickerNone {
+		return false
+	}
+
+	v := m.pickerValue
+	if m.picker == pickerDate {
+		switch {
+		case key.Matches(msg, m.keys.Left):
+			v = v.AddDate(0, 0, -1)
+		case key.Matches(msg, m.keys.Right):
+			v = v.AddDate(0, 0, 1)
+		case key.Matches(msg, m.keys.Up):
+			v = v.AddDate(0, 0, -7)
+		case key.Matches(msg, m.keys.Down):
+			v = v.AddDate(0, 0, 7)
+		case key.Matches(msg, m.keys.PageUp):
+			v = addMonths(v, -1)
+		case key.Matches(msg, m.keys.PageDown):
+			v = addMonths(v, 1)
+		}
+	} else {
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			v = v.Add(-pickerStep)
+		case key.Matches(msg, m.keys.Down):
+			v = v.Add(pickerStep)
+		case key.Matches(msg, m.keys.Left, m.keys.PageUp):
+			v = v.Add(-time.Hour)
+		case key.Matches(msg, m.keys.Right, m.keys.PageDown):
+			v = v.Add(time.Hour)
+		}
+	}
+	m.pickerValue = v
+
+	switch {
+	case key.Matches(msg, m.keys.Search, m.keys.Toggle):
+		m.applyPicker()
+	case key.Matches(msg, m.keys.Close):
+		m.picker = pickerNone
+	case key.Matches(msg, m.keys.Next, m.keys.Prev):
+		m.picker = pickerNone
+		return false
+	case key.Matches(msg, m.keys.Quit):
+		return false
+	}
+	return true
+}
+
+// applyPicker writes the picked value back in the format the inputs use.
+func (m *model) applyPicker() {
+	switch m.picker {
+	case pickerDate:
+		// Keep a time typed together with the date, like "tomorrow 7:30"
+		if when, err := utils.ParseDate(m.inputs[2].Value(), time.Now()); err == nil && when.HasTime && m.inputs[3].Value() == "" {
+			m.inputs[3].SetValue(when.Time.Format("15:04"))
+		}
+		m.inputs[2].SetValue(m.pickerValue.Format("2006-01-02"))
+		m.inputs[2].CursorEnd()
+	case pickerTime:
+		m.inputs[3].SetValue(m.pickerValue.Format("15:04"))
+		m.inputs[3].CursorEnd()
+	}
+	m.picker = pickerNone
+	m.revalidate()
+}
+
+func (m model) renderPicker() string {
+	if m.picker == pickerDate {
+		return suggestionStyle.Render(renderCalendar(m.pickerValue, time.Now()))
+	}
+	return suggestionStyle.Render(renderTimeWheel(m.pickerValue))
+}
+
+// renderCalendar draws the month of the selected day, weeks starting on
+// Monday.
+func renderCalendar(selected, now time.Time) string {
+	first := time.Date(selected.Year(), selected.Month(), 1, 0, 0, 0, 0, selected.Location())
+	offset := (int(first.Weekday()) + 6) % 7
+
+	title := lipgloss.PlaceHorizontal(calendarWidth, lipgloss.Center, noStyle.Bold(true).Render(first.Format("January 2006")))
+	lines := []string{title, noStyle.Foreground(sbbGray).Render("Mo Tu We Th Fr Sa Su")}
+
+	var week []string
+	for range offset {
+		week = append(week, "  ")
+	}
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		style := noStyle
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			style = style.Foreground(sbbRed)
+		}
+		if sameDay(d, now) {
+			style = style.Bold(true).Underline(true)
+		}
+		if sameDay(d, selected) {
+			style = style.Background(sbbRed).Foreground(sbbWhite).Bold(true)
+		}
+		week = append(week, style.Render(fmt.Sprintf("%2d", d.Day())))
+
+		if len(week) == 7 {
+			lines = append(lines, strings.Join(week, " "))
+			week = nil
+		}
+	}
+	if len(week) > 0 {
+		lines = append(lines, strings.Join(week, " "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderTimeWheel lists the steps around the selected time.
+func renderTimeWheel(selected time.Time) string {
+	var lines []string
+	for i := -pickerRows / 2; i <= pickerRows/2; i++ {
+		t := selected.Add(time.Duration(i) * pickerStep).Format("15:04")
+		if i == 0 {
+			lines = append(lines, noStyle.Background(sbbRed).Foreground(sbbWhite).Bold(true).Render(" "+t+" "))
+		} else {
+			lines = append(lines, noStyle.Foreground(sbbGray).Render(" "+t+" "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// addMonths moves by whole months, ending on the last day of a shorter
+// month instead of running over into the next one.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
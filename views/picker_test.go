@@ -0,0 +1,122 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// focusField tabs through the header until the field with the given id has
+// the focus.
+func focusField(t *testing.T, m model, id string) model {
+	t.Helper()
+	for range len(m.headerOrder) {
+		if m.headerOrder[m.tabIndex].id == id {
+			return m
+		}
+		m = press(t, m, tea.KeyTab)
+	}
+	t.Fatalf("no header field %q", id)
+	return m
+}
+
+func TestGoldenCalendar(t *testing.T) {
+	// October 2026 starts on a Thursday
+	selected := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, time.October, 14, 9, 0, 0, 0, time.UTC)
+	calendar := renderCalendar(selected, now)
+	golden(t, "calendar", calendar)
+
+	lines := strings.Split(ansi.Strip(calendar), "\n")
+	if !strings.HasPrefix(lines[1], "Mo") {
+		t.Errorf("weeks start with %q, want Monday", lines[1])
+	}
+	if want := strings.Repeat(" ", 3*3) + " 1"; !strings.HasPrefix(lines[2], want) {
+		t.Errorf("first week is %q, want it to start on Thursday", lines[2])
+	}
+	if !strings.Contains(calendar, noStyle.Background(sbbRed).Foreground(sbbWhite).Bold(true).Render("16")) {
+		t.Error("the selected day is not highlighted")
+	}
+	if !strings.Contains(calendar, noStyle.Bold(true).Underline(true).Render("14")) {
+		t.Error("today is not marked")
+	}
+}
+
+func TestDatePicker(t *testing.T) {
+	tests := []struct {
+		value string
+		keys  []tea.KeyType
+		want  string
+	}{
+		{"2026-10-16", []tea.KeyType{tea.KeyRight, tea.KeyDown}, "2026-10-24"},
+		{"2026-10-31", []tea.KeyType{tea.KeyRight}, "2026-11-01"},
+		{"2026-03-01", []tea.KeyType{tea.KeyUp}, "2026-02-22"},
+		// Moving by months stays on the last day of a shorter one
+		{"2026-01-31", []tea.KeyType{tea.KeyPgDown}, "2026-02-28"},
+		{"2028-03-31", []tea.KeyType{tea.KeyPgUp}, "2028-02-29"},
+		{"2026-12-15", []tea.KeyType{tea.KeyPgDown}, "2027-01-15"},
+		{"24.12.2026", []tea.KeyType{tea.KeyLeft}, "2026-12-23"},
+	}
+
+	for _, tt := range tests {
+		m := newTestModel(t, 160, 40)
+		m = focusField(t, m, "date")
+		m.inputs[2].SetValue(tt.value)
+
+		m = press(t, m, tea.KeyCtrlP)
+		if m.picker != pickerDate {
+			t.Fatal("the date picker did not open")
+		}
+		m = press(t, m, tt.keys...)
+		m = press(t, m, tea.KeyEnter)
+
+		if m.picker != pickerNone {
+			t.Errorf("%s: the picker is still open", tt.value)
+		}
+		if got := m.inputs[2].Value(); got != tt.want {
+			t.Errorf("%s: picked %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTimePicker(t *testing.T) {
+	tests := []struct {
+		value string
+		keys  []tea.KeyType
+		want  string
+	}{
+		{"7:30", []tea.KeyType{tea.KeyDown}, "07:35"},
+		{"7:32", nil, "07:30"},
+		{"23:55", []tea.KeyType{tea.KeyDown}, "00:00"},
+		{"0:00", []tea.KeyType{tea.KeyLeft}, "23:00"},
+	}
+
+	for _, tt := range tests {
+		m := newTestModel(t, 160, 40)
+		m = focusField(t, m, "time")
+		m.inputs[3].SetValue(tt.value)
+
+		m = press(t, m, tea.KeyCtrlP)
+		if m.picker != pickerTime {
+			t.Fatal("the time picker did not open")
+		}
+		m = press(t, m, tt.keys...)
+		m = press(t, m, tea.KeyEnter)
+
+		if got := m.inputs[3].Value(); got != tt.want {
+			t.Errorf("%s: picked %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	// Closing the picker keeps the typed value
+	m := newTestModel(t, 160, 40)
+	m = focusField(t, m, "time")
+	m.inputs[3].SetValue("7:32")
+	m = press(t, m, tea.KeyCtrlP, tea.KeyDown, tea.KeyEsc)
+	if m.picker != pickerNone || m.inputs[3].Value() != "7:32" {
+		t.Errorf("closed picker left %q, want the typed 7:32", m.inputs[3].Value())
+	}
+}
@@ -0,0 +1,155 @@
+package views
+
+import (
+	"slices"
+	"time"
+
+	"sbb-tui/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type refreshTickMsg struct {
+	id int
+}
+
+type RefreshMsg struct {
+	id          int
+	connections []models.Connection
+	err         error
+}
+
+// stopChange flags what changed at a stop during the last refresh.
+type stopChange struct {
+	delay    bool
+	platform bool
+}
+
+type sectionChange struct {
+	departure stopChange
+	arrival   stopChange
+}
+
+type connectionChange struct {
+	platform bool
+	sections []sectionChange
+}
+
+func (c connectionChange) section(i int) sectionChange {
+	if i < len(c.sections) {
+		return c.sections[i]
+	}
+	return sectionChange{}
+}
+
+// scheduleRefresh queues the next live update of the current search.
+func (m model) scheduleRefresh() tea.Cmd {
+	if m.refreshInterval <= 0 || m.mode != ModeConnections {
+		return nil
+	}
+
+	id := m.searchID
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{id: id}
+	})
+}
+
+func (m model) refreshCmd() tea.Cmd {
+	provider, ctx, id, input := m.provider, m.searchCtx, m.searchID, m.query
+	return func() tea.Msg {
+		res, err := provider.Connections(ctx, input)
+		return RefreshMsg{id: id, connections: res, err: err}
+	}
+}
+
+// handleRefreshTick fires the refresh unless another request of the same
+// search is running, in which case it waits for the next tick.
+func (m *model) handleRefreshTick(msg refreshTickMsg) tea.Cmd {
+	if msg.id != m.searchID {
+		return nil
+	}
+	if m.loading || m.refreshing || m.paging {
+		return m.scheduleRefresh()
+	}
+	return m.refreshCmd()
+}
+
+// applyRefresh updates the listed connections in place, matched by journey
+// identity, and remembers what changed so the view can highlight it.
+// Connections missing from the refreshed page are kept as they are.
+func (m *model) applyRefresh(msg RefreshMsg) tea.Cmd {
+	if msg.id != m.searchID {
+		return nil
+	}
+	if msg.err != nil {
+		return m.scheduleRefresh()
+	}
+
+	fresh := make(map[string]models.Connection, len(msg.connections))
+	for _, c := range msg.connections {
+		fresh[connectionKey(c)] = c
+	}
+
+	changes := make(map[string]connectionChange)
+	updated := slices.Clone(m.connections)
+	for i, old := range updated {
+		key := connectionKey(old)
+		c, ok := fresh[key]
+		if !ok {
+			continue
+		}
+		if change, changed := diffConnection(old, c); changed {
+			changes[key] = change
+		}
+		updated[i] = c
+	}
+
+	// Connections that filled up since are hidden like in a new search
+	m.connections = m.filterCrowded(updated)
+	if len(m.connections) < len(updated) {
+		m.resultIndex = min(m.resultIndex, max(len(m.connections)-1, 0))
+		m.scrollResults()
+		if len(m.connections) == 0 {
+			m.errorMsg = "Every connection found is crowded in 2nd class."
+		}
+	}
+	m.changes = changes
+	m.cacheResults(msg.connections)
+	m.cachedAt = time.Time{}
+	m.offline = false
+	return m.scheduleRefresh()
+}
+
+func diffConnection(old, fresh models.Connection) (connectionChange, bool) {
+	change := connectionChange{
+		platform: departurePlatform(old) != departurePlatform(fresh),
+	}
+	changed := change.platform
+
+	for i := range min(len(old.Sections), len(fresh.Sections)) {
+		o, f := old.Sections[i], fresh.Sections[i]
+		s := sectionChange{
+			departure: stopChange{
+				delay:    o.Departure.Delay != f.Departure.Delay,
+				platform: o.Departure.ExpectedPlatform() != f.Departure.ExpectedPlatform(),
+			},
+			arrival: stopChange{
+				delay:    o.Arrival.Delay != f.Arrival.Delay,
+				platform: o.Arrival.ExpectedPlatform() != f.Arrival.ExpectedPlatform(),
+			},
+		}
+		change.sections = append(change.sections, s)
+		changed = changed || s.departure != (stopChange{}) || s.arrival != (stopChange{})
+	}
+
+	return change, changed
+}
+
+// departurePlatform is the platform of the connection as expected, the
+// prognosis of the first section moving it.
+func departurePlatform(c models.Connection) string {
+	if len(c.Sections) > 0 && c.Sections[0].Departure.Platform == c.FromData.Platform {
+		return c.Sections[0].Departure.ExpectedPlatform()
+	}
+	return c.FromData.Platform
+}
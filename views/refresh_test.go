@@ -0,0 +1,120 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sbb-tui/config"
+	"sbb-tui/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDiffConnectionPrognosisPlatform(t *testing.T) {
+	old := fixtureConnections(t)[0]
+	fresh := fixtureConnections(t)[0]
+	if _, changed := diffConnection(old, fresh); changed {
+		t.Fatal("identical connections differ")
+	}
+
+	fresh.Sections[0].Departure.Prognosis = &models.Prognosis{Platform: "12"}
+	change, changed := diffConnection(old, fresh)
+	if !changed || !change.platform || !change.section(0).departure.platform {
+		t.Errorf("diffConnection() = %+v, want the departure platform changed", change)
+	}
+
+	// The list and the journey highlight the platform the train now uses
+	m := newGoldenModel(t, []models.Connection{fresh}, 160, 40)
+	m.changes = map[string]connectionChange{connectionKey(fresh): change}
+	view := m.View()
+	if got := strings.Count(view, changedStyle.Render("12")); got != 2 {
+		t.Errorf("platform 12 is highlighted %d times, want in the list and the journey:\n%s", got, view)
+	}
+	if strings.Contains(view, changedStyle.Render("7")) {
+		t.Errorf("the scheduled platform is highlighted:\n%s", view)
+	}
+	if compact := m.renderCompactConnection(fresh, 0, 80); !strings.Contains(compact, pltIcon+" 12") {
+		t.Errorf("compact line %q is missing platform 12", compact)
+	}
+
+	// Announcing the scheduled platform again is no change
+	old.Sections[0].Departure.Prognosis = &models.Prognosis{Platform: old.Sections[0].Departure.Platform}
+	fresh.Sections[0].Departure.Prognosis = nil
+	if _, changed := diffConnection(old, fresh); changed {
+		t.Error("a prognosis on the scheduled platform counts as a change")
+	}
+}
+
+func crowded(c models.Connection) models.Connection {
+	c.Capacity2nd = models.CapacityHigh
+	return c
+}
+
+func TestRefreshHidesCrowded(t *testing.T) {
+	m := newGoldenModel(t, nil, 160, 40)
+	m.hideCrowded = true
+	// The second fixture connection is crowded already
+	m.connections = m.filterCrowded(fixtureConnections(t))
+	m.resultIndex = 2
+
+	fresh := fixtureConnections(t)
+	fresh[3] = crowded(fresh[3])
+	m.applyRefresh(RefreshMsg{id: m.searchID, connections: fresh})
+
+	if len(m.connections) != 2 || m.hiddenCrowded != 2 {
+		t.Errorf("got %d connections and %d hidden, want 2 and 2", len(m.connections), m.hiddenCrowded)
+	}
+	if m.resultIndex != 1 {
+		t.Errorf("selection = %d, want it moved to the last connection", m.resultIndex)
+	}
+}
+
+func TestCrowdedPageStatus(t *testing.T) {
+	m := newGoldenModel(t, fixtureConnections(t)[:2], 160, 40)
+	m.hideCrowded = true
+
+	page := fixtureConnections(t)[2:]
+	for i := range page {
+		page[i] = crowded(page[i])
+	}
+	m.mergePage(PageMsg{id: m.searchID, page: 1, connections: page})
+
+	if want := "Only crowded later connections, 2 hidden."; m.pageStatus != want {
+		t.Errorf("page status = %q, want %q", m.pageStatus, want)
+	}
+}
+
+func TestCancelKeepsRefreshing(t *testing.T) {
+	m := newGoldenModel(t, fixtureConnections(t), 160, 40, func(cfg *config.Config) {
+		cfg.Refresh.Interval = time.Millisecond
+	})
+	m.resultIndex = len(m.connections) - 1
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	if !m.paging {
+		t.Fatal("no later page is loading")
+	}
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(model)
+	if m.paging {
+		t.Fatal("the page is still loading")
+	}
+
+	var tick tea.Msg
+	for _, msg := range run(cmd) {
+		if _, ok := msg.(refreshTickMsg); ok {
+			tick = msg
+		}
+	}
+	if tick == nil {
+		t.Fatal("cancelling the page stopped the refresh")
+	}
+	msgs := run(m.handleRefreshTick(tick.(refreshTickMsg)))
+	if len(msgs) != 1 {
+		t.Fatalf("the new tick returned %v, want a refresh", msgs)
+	}
+	if _, ok := msgs[0].(RefreshMsg); !ok {
+		t.Errorf("the new tick returned %T, want a refresh", msgs[0])
+	}
+}
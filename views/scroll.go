@@ -0,0 +1,62 @@
+package views
+
+import (
+	"strings"
+)
+
+var (
+	// Scroll indicator
+	scrollTrack = "│"
+	scrollThumb = "┃"
+)
+
+// resultWindow returns the range of connections shown in the list, starting
+// from the stored offset but always keeping the selected one in view.
+func (m model) resultWindow() (start, end int) {
+	visible := m.maxVisibleConnections()
+
+	// Give up a slot when the pagination status would not fit below the list
+	if m.statusLine() != "" && m.resultsHeight()-visible*m.connHeight() < 1 {
+		visible = max(visible-1, 1)
+	}
+
+	start = min(m.resultOffset, m.resultIndex)
+	start = max(start, m.resultIndex-visible+1, 0)
+	end = min(start+visible, len(m.connections))
+	return start, end
+}
+
+func (m *model) scrollResults() {
+	m.resultOffset, _ = m.resultWindow()
+}
+
+// moveResult shifts the selection by delta, clamped to the loaded results.
+func (m *model) moveResult(delta int) {
+	if len(m.connections) == 0 {
+		return
+	}
+	m.resultIndex = min(max(m.resultIndex+delta, 0), len(m.connections)-1)
+	m.scrollResults()
+}
+
+func renderScrollbar(height, total, start, visible int) string {
+	if total <= visible || height <= 0 {
+		return ""
+	}
+
+	thumbHeight := max(height*visible/total, 1)
+	thumbStart := min(height*start/total, height-thumbHeight)
+	if start+visible >= total {
+		thumbStart = height - thumbHeight
+	}
+
+	lines := make([]string, height)
+	for i := range lines {
+		if i >= thumbStart && i < thumbStart+thumbHeight {
+			lines[i] = noStyle.Foreground(sbbRed).Render(scrollThumb)
+		} else {
+			lines[i] = noStyle.Foreground(sbbMidGray).Render(scrollTrack)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
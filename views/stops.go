@@ -0,0 +1,86 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"sbb-tui/models"
+)
+
+// intermediateStops drops the departure and arrival stations, which the
+// passList repeats at both ends.
+func intermediateStops(s models.Section) []models.Stop {
+	if s.Journey == nil || len(s.Journey.PassList) < 3 {
+		return nil
+	}
+	return s.Journey.PassList[1 : len(s.Journey.PassList)-1]
+}
+
+// toggleStops expands the intermediate stops of the next journey section of
+// the selected connection, collapsing them again after the last one.
+func (m *model) toggleStops() {
+	if len(m.connections) == 0 {
+		return
+	}
+
+	c := m.connections[m.resultIndex]
+	key := connectionKey(c)
+	start := 0
+	if m.stopsKey == key {
+		start = m.stopsSection + 1
+	}
+
+	m.stopsKey = ""
+	for i := start; i < len(c.Sections); i++ {
+		if len(intermediateStops(c.Sections[i])) > 0 {
+			m.stopsKey = key
+			m.stopsSection = i
+			return
+		}
+	}
+}
+
+func (m model) stopsExpanded(c models.Connection, section int) bool {
+	return m.stopsKey == connectionKey(c) && m.stopsSection == section
+}
+
+func (m model) renderStops(stops []models.Stop, width, timeCol, delayCol, symbolCol int) []string {
+	var lines []string
+	for _, stop := range stops {
+		t := stop.Arrival
+		if t.IsZero() {
+			t = stop.Departure
+		}
+		timeStr := strings.Repeat(" ", timeCol)
+		if !t.IsZero() {
+			timeStr = t.Local().Format("15:04")
+		}
+
+		station := stop.Station.Name
+		if stop.Cancelled {
+			station = noStyle.Strikethrough(true).Render(station)
+		}
+		lines = append(lines, m.formatStationLine(timeStr, stop.Delay, hollowDot, station, stop.Platform,
+			width, timeCol, delayCol, symbolCol, false, stopChange{}))
+	}
+	return lines
+}
+
+func stopsHint(n int) string {
+	if n == 1 {
+		return "1 stop"
+	}
+	return fmt.Sprintf("%d stops", n)
+}
+
+// clipLines keeps the detail view inside its box, marking what was cut.
+func clipLines(lines []string, height int) []string {
+	if len(lines) <= height {
+		return lines
+	}
+	if height < 1 {
+		return nil
+	}
+	lines = lines[:height-1]
+	return append(lines, noStyle.Foreground(sbbGray).Render("  ..."))
+}
@@ -0,0 +1,173 @@
+package views
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"sbb-tui/models"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// Station autocomplete
+	suggestDebounce  = 250 * time.Millisecond
+	suggestMinChars  = 2
+	suggestMaxItems  = 6
+	suggestBoxMinLen = 24
+)
+
+type suggestTickMsg struct {
+	id    int
+	field int
+	query string
+}
+
+type LocationsMsg struct {
+	id        int
+	field     int
+	locations []models.Location
+	err       error
+}
+
+// isStationField reports whether the input at index i takes a station name.
+func isStationField(i int) bool {
+	return i == 0 || i == 1
+}
+
+func (m model) suggestionsVisible() bool {
+	if len(m.suggestions) == 0 {
+		return false
+	}
+	active := m.headerOrder[m.tabIndex]
+	return active.kind == KindInput && active.index == m.suggestField
+}
+
+func (m *model) clearSuggestions() {
+	m.suggestions = nil
+	m.suggestIndex = 0
+	m.suggestID++
+}
+
+// scheduleSuggestions debounces the locations lookup for the given field;
+// only the tick matching the latest id triggers a request.
+func (m *model) scheduleSuggestions(field int) tea.Cmd {
+	m.clearSuggestions()
+
+	query := strings.TrimSpace(m.inputs[field].Value())
+	if utf8.RuneCountInString(query) < suggestMinChars {
+		return nil
+	}
+
+	id := m.suggestID
+	return tea.Tick(suggestDebounce, func(time.Time) tea.Msg {
+		return suggestTickMsg{id: id, field: field, query: query}
+	})
+}
+
+func (m model) suggestCmd(msg suggestTickMsg) tea.Cmd {
+	provider := m.provider
+	return func() tea.Msg {
+		res, err := provider.Locations(context.Background(), msg.query)
+		return LocationsMsg{id: msg.id, field: msg.field, locations: res, err: err}
+	}
+}
+
+func (m *model) setSuggestions(msg LocationsMsg) {
+	if msg.id != m.suggestID || msg.err != nil {
+		return
+	}
+
+	// Nothing to suggest once the input already holds the canonical name
+	current := strings.TrimSpace(m.inputs[msg.field].Value())
+	if len(msg.locations) == 1 && strings.EqualFold(msg.locations[0].Name, current) {
+		return
+	}
+
+	var locations []models.Location
+	for _, l := range msg.locations {
+		if l.Name == "" {
+			continue
+		}
+		locations = append(locations, l)
+		if len(locations) == suggestMaxItems {
+			break
+		}
+	}
+
+	m.suggestions = locations
+	m.suggestField = msg.field
+	m.suggestIndex = 0
+}
+
+func (m *model) acceptSuggestion() {
+	name := m.suggestions[m.suggestIndex].Name
+	m.inputs[m.suggestField].SetValue(name)
+	m.inputs[m.suggestField].CursorEnd()
+	m.clearSuggestions()
+}
+
+// updateSuggestions handles dropdown navigation. The returned bool reports
+// whether the key was consumed.
+func (m *model) updateSuggestions(msg tea.KeyMsg) bool {
+	if !m.suggestionsVisible() {
+		return false
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.suggestIndex > 0 {
+			m.suggestIndex--
+		}
+		return true
+	case key.Matches(msg, m.keys.Down):
+		if m.suggestIndex < len(m.suggestions)-1 {
+			m.suggestIndex++
+		}
+		return true
+	case key.Matches(msg, m.keys.Search):
+		m.acceptSuggestion()
+		return true
+	case key.Matches(msg, m.keys.Next):
+		// Accept, then let the regular tab handling move the focus on
+		m.acceptSuggestion()
+		return false
+	case key.Matches(msg, m.keys.Close):
+		m.clearSuggestions()
+		return true
+	}
+	return false
+}
+
+// suggestionsAnchor returns the header position and width of the field the
+// dropdown belongs to.
+func (m model) suggestionsAnchor() (x, y, width int) {
+	for i, item := range m.headerOrder {
+		if item.kind == KindInput && item.index == m.suggestField {
+			x, y = m.headerAnchor(item.id)
+			return x, y, lipgloss.Width(m.renderHeaderItem(i))
+		}
+	}
+	return 0, hdrHeight, 0
+}
+
+func (m model) renderSuggestions(width int) string {
+	frame := suggestionStyle.GetHorizontalFrameSize()
+	width = max(width, suggestBoxMinLen) - frame
+
+	var lines []string
+	for i, l := range m.suggestions {
+		name := truncateString(l.Name, width)
+		line := noStyle.Width(width).Render(name)
+		if i == m.suggestIndex {
+			line = noStyle.Width(width).Background(sbbRed).Foreground(sbbWhite).Bold(true).Render(name)
+		}
+		lines = append(lines, line)
+	}
+
+	return suggestionStyle.Render(strings.Join(lines, "\n"))
+}
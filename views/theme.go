@@ -0,0 +1,77 @@
+package views
+
+import (
+	"sbb-tui/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// applyTheme swaps the package colors for the configured palette.
+func applyTheme(t config.Theme) {
+	p := t.Palette()
+	sbbRed = lipgloss.Color(p.Accent)
+	sbbDarkRed = lipgloss.Color(p.AccentDark)
+	sbbBlue = lipgloss.Color(p.Vehicle)
+	sbbMidGray = lipgloss.Color(p.Border)
+	sbbWhite = lipgloss.Color(p.Foreground)
+	sbbBlack = lipgloss.Color(p.Background)
+	sbbGray = lipgloss.Color(p.Muted)
+	buildStyles()
+}
+
+func applyIcons(icons map[string]string) {
+	targets := map[string]*string{
+		"arrival":     &arrIcon,
+		"board":       &brdIcon,
+		"connections": &cnnIcon,
+		"departure":   &dptIcon,
+		"platform":    &pltIcon,
+		"prompt":      &prmptIcon,
+		"search":      &srchIcon,
+		"swap":        &swpIcon,
+		"vehicle":     &vhcIcon,
+		"walk":        &wlkIcon,
+		"warning":     &wrnIcon,
+		"watch":       &wtchIcon,
+
+		"arrow":           &arrow,
+		"dot":             &filledDot,
+		"hollow_dot":      &hollowDot,
+		"hline":           &horzLine,
+		"vline":           &vertLine,
+		"capacity_low":    &capacityBars[0],
+		"capacity_medium": &capacityBars[1],
+		"capacity_high":   &capacityBars[2],
+		"scroll_track":    &scrollTrack,
+		"scroll_thumb":    &scrollThumb,
+		"separator":       &separator,
+		"marker":          &selMarker,
+	}
+
+	for name, icon := range icons {
+		if target, ok := targets[name]; ok {
+			*target = icon
+		}
+	}
+}
+
+var (
+	// Plain borders for terminals without box drawing characters
+	asciiBorder = lipgloss.Border{
+		Top: "-", Bottom: "-", Left: "|", Right: "|",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+	}
+	asciiThickBorder = lipgloss.Border{
+		Top: "=", Bottom: "=", Left: "#", Right: "#",
+		TopLeft: "#", TopRight: "#", BottomLeft: "#", BottomRight: "#",
+	}
+)
+
+// applyBorders draws the boxes with plain ASCII for the ascii icon set.
+func applyBorders(set string) {
+	boxBorder, errorBorder = lipgloss.RoundedBorder(), lipgloss.ThickBorder()
+	if set == config.IconSetASCII {
+		boxBorder, errorBorder = asciiBorder, asciiThickBorder
+	}
+	buildStyles()
+}
@@ -0,0 +1,76 @@
+package views
+
+import (
+	"slices"
+	"strings"
+
+	"sbb-tui/models"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const transportMenuWidth = 12
+
+// updateTransportMenu handles the transportations checklist. The returned
+// bool reports whether the key was consumed.
+func (m *model) updateTransportMenu(msg tea.KeyMsg) bool {
+	if !m.transportMenu {
+		return false
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.transportIndex > 0 {
+			m.transportIndex--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.transportIndex < len(models.Transportations)-1 {
+			m.transportIndex++
+		}
+	case key.Matches(msg, m.keys.Toggle):
+		m.toggleTransportation(models.Transportations[m.transportIndex])
+	case key.Matches(msg, m.keys.Search, m.keys.Close):
+		m.transportMenu = false
+	case key.Matches(msg, m.keys.Next, m.keys.Prev):
+		m.transportMenu = false
+		return false
+	case key.Matches(msg, m.keys.Quit):
+		return false
+	}
+	return true
+}
+
+func (m *model) toggleTransportation(t string) {
+	if i := slices.Index(m.transportations, t); i >= 0 {
+		m.transportations = slices.Delete(slices.Clone(m.transportations), i, i+1)
+		return
+	}
+
+	// Keep the API order so the query stays stable
+	var selected []string
+	for _, known := range models.Transportations {
+		if known == t || slices.Contains(m.transportations, known) {
+			selected = append(selected, known)
+		}
+	}
+	m.transportations = selected
+}
+
+func (m model) renderTransportMenu() string {
+	var lines []string
+	for i, t := range models.Transportations {
+		dot := hollowDot
+		if slices.Contains(m.transportations, t) {
+			dot = filledDot
+		}
+
+		line := noStyle.Width(transportMenuWidth).Render(dot + " " + t)
+		if i == m.transportIndex {
+			line = noStyle.Width(transportMenuWidth).Background(sbbRed).Foreground(sbbWhite).Bold(true).Render(dot + " " + t)
+		}
+		lines = append(lines, line)
+	}
+
+	return suggestionStyle.Render(strings.Join(lines, "\n"))
+}
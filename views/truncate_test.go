@@ -0,0 +1,75 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		s      string
+		maxLen int
+		want   string
+	}{
+		{"Zürich HB", 20, "Zürich HB"},
+		{"Zürich HB", 9, "Zürich HB"},
+		{"Zürich HB", 6, "Zür..."},
+		{"Genève-Aéroport", 10, "Genève-..."},
+		{"Genève-Aéroport", 3, "Gen"},
+		{"Zürich", 5, "Zü..."},
+		// A decomposed ü stays in one piece
+		{"Zu\u0308rich", 5, "Zu\u0308..."},
+		{"Zürich", 0, ""},
+	}
+
+	for _, tt := range tests {
+		got := truncateString(tt.s, tt.maxLen)
+		if got != tt.want {
+			t.Errorf("truncateString(%q, %d) = %q, want %q", tt.s, tt.maxLen, got, tt.want)
+		}
+		if w := lipgloss.Width(got); w > tt.maxLen {
+			t.Errorf("truncateString(%q, %d) is %d cells wide", tt.s, tt.maxLen, w)
+		}
+	}
+}
+
+func TestWrapString(t *testing.T) {
+	tests := []struct {
+		s      string
+		width  int
+		indent string
+		want   []string
+	}{
+		{"→ Luzern", 20, "  │   ", []string{"→ Luzern"}},
+		{"  │   → Luzern (2 stops, ctrl+o to show)", 24, "  │   ", []string{
+			"  │   → Luzern (2 stops,",
+			"  │   ctrl+o to show)",
+		}},
+		{"! Between Olten and Aarau only one track is in service.", 20, "  ", []string{
+			"! Between Olten and",
+			"  Aarau only one",
+			"  track is in",
+			"  service.",
+		}},
+		// Without room after the indent the lines wrap as they are
+		{"Olten Aarau", 6, "      ", []string{"Olten", "Aarau"}},
+	}
+
+	for _, tt := range tests {
+		got := wrapString(tt.s, tt.width, tt.indent)
+		if len(got) != len(tt.want) {
+			t.Errorf("wrapString(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("wrapString(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+				break
+			}
+			if w := lipgloss.Width(got[i]); w > tt.width {
+				t.Errorf("wrapString(%q, %d) has a line %d cells wide", tt.s, tt.width, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package views
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sbb-tui/api/fixtures"
+	"sbb-tui/config"
+	"sbb-tui/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// newTestModel runs the interface on the recorded responses, keeping the
+// user's data directories out of it.
+func newTestModel(t *testing.T, width, height int, options ...func(*config.Config)) model {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cfg := config.Default()
+	cfg.Cache.Enabled = false
+	cfg.Defaults.Limit = 4
+	cfg.Watch.Bell, cfg.Watch.Desktop = false, false
+	cfg.SetIconSet(config.IconSetNerdfont)
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	m := InitialModel(cfg, fixtures.New())
+	return update(t, m, tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// update feeds msg to the model along with the messages of the commands it
+// returns. Commands still running after a short while, like ticks, are
+// dropped.
+func update(t *testing.T, m model, msg tea.Msg) model {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	m = next.(model)
+	for _, msg := range run(cmd) {
+		m = update(t, m, msg)
+	}
+	return m
+}
+
+func run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(20 * time.Millisecond):
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case nil:
+		return nil
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, c := range msg {
+			msgs = append(msgs, run(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+func typeText(t *testing.T, m model, s string) model {
+	t.Helper()
+	for _, r := range s {
+		m = update(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+// breakDataDir puts a file where the data directory goes, so that saving
+// favorites or the history fails.
+func breakDataDir(t *testing.T) {
+	t.Helper()
+	dir := filepath.Join(os.Getenv("XDG_DATA_HOME"), "sbb-tui")
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func press(t *testing.T, m model, keys ...tea.KeyType) model {
+	t.Helper()
+	for _, k := range keys {
+		m = update(t, m, tea.KeyMsg{Type: k})
+	}
+	return m
+}
+
+func TestSearchConnections(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Zürich")
+	m = press(t, m, tea.KeyEnter)
+
+	if len(m.connections) != 4 {
+		t.Fatalf("got %d connections, want 4 (error %q)", len(m.connections), m.errorMsg)
+	}
+	view := m.View()
+	for _, want := range []string{"IC 1", "St. Gallen", "Zürich HB"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q", want)
+		}
+	}
+
+	// The second connection carries a disruption and a platform change
+	m = press(t, m, tea.KeyDown)
+	if view := m.View(); !strings.Contains(view, "Construction work") || !strings.Contains(view, "platform 10") {
+		t.Errorf("detail view is missing the warnings:\n%s", view)
+	}
+}
+
+// send feeds msg to the model without running the commands it returns.
+func send(t *testing.T, m model, msg tea.Msg) model {
+	t.Helper()
+	next, _ := m.Update(msg)
+	return next.(model)
+}
+
+func TestStaleRepliesAreDropped(t *testing.T) {
+	all := fixtureConnections(t)
+	m := newTestModel(t, 160, 40)
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Zürich")
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	first := m.searchID
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	second := m.searchID
+	if first == second {
+		t.Fatal("the second search kept the id of the first")
+	}
+
+	// The newer reply arrives first, the superseded one after it
+	m = send(t, m, DataMsg{id: second, connections: all[:2]})
+	m = send(t, m, DataMsg{id: first, connections: all})
+	if len(m.connections) != 2 || m.loading {
+		t.Errorf("got %d connections, loading %v, want the 2 of the second search", len(m.connections), m.loading)
+	}
+
+	// A late error of the first search does not replace the results either
+	m = send(t, m, DataMsg{id: first, err: context.Canceled})
+	if m.errorMsg != "" || len(m.connections) != 2 {
+		t.Errorf("stale error %q replaced the results", m.errorMsg)
+	}
+}
+
+func TestCancelIgnoresLateReply(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Zürich")
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	id := m.searchID
+	if !m.loading {
+		t.Fatal("search is not loading")
+	}
+	ctx := m.searchCtx
+
+	m = send(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.loading || m.errorMsg != "Search cancelled." {
+		t.Errorf("loading %v with error %q, want the search cancelled", m.loading, m.errorMsg)
+	}
+	if ctx.Err() == nil {
+		t.Error("the request of the cancelled search is still running")
+	}
+
+	m = send(t, m, DataMsg{id: id, connections: fixtureConnections(t)})
+	if len(m.connections) != 0 || m.errorMsg != "Search cancelled." {
+		t.Errorf("the late reply was shown: %d connections, error %q", len(m.connections), m.errorMsg)
+	}
+}
+
+func TestStationSuggestions(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+
+	m = typeText(t, m, "Bern")
+	// Skip the debounce
+	m = update(t, m, suggestTickMsg{id: m.suggestID, field: 0, query: "Bern"})
+	if len(m.suggestions) == 0 || m.suggestions[0].Name != "Bern" {
+		t.Fatalf("suggestions = %v, want Bern first", m.suggestions)
+	}
+	if !strings.Contains(m.View(), "Bern Wankdorf") {
+		t.Error("view is missing the suggestion Bern Wankdorf")
+	}
+}
+
+func TestDepartureBoard(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+
+	m = press(t, m, tea.KeyShiftTab)
+	m = typeText(t, m, " ")
+	if m.mode != ModeBoard {
+		t.Fatal("mode button did not switch to the departure board")
+	}
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyEnter)
+
+	view := m.View()
+	for _, want := range []string{"Departures from Bern", "St. Gallen", "Interlaken Ost"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("board is missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestBoardKeepsItsQueryLabels(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+
+	m = press(t, m, tea.KeyShiftTab)
+	m = typeText(t, m, " ")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyEnter)
+
+	// Toggling the switch after the search does not relabel the departures
+	m.isArrivalTime = true
+	view := m.View()
+	if !strings.Contains(view, "Departures from Bern") || strings.Contains(view, "Arrivals at") {
+		t.Errorf("board is relabelled by the switch:\n%s", view)
+	}
+	if !strings.Contains(view, "Luzern") {
+		t.Errorf("board lost its destinations:\n%s", view)
+	}
+}
+
+func TestBoardFitsTheScreen(t *testing.T) {
+	m := newTestModel(t, 120, 30)
+	m.toggleMode()
+
+	board, err := fixtures.New().Stationboard(context.Background(), models.Input{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for len(m.board) < 32 {
+		m.board = append(m.board, board...)
+	}
+	m.searched = true
+
+	if got := lipgloss.Height(m.View()); got != 30 {
+		t.Errorf("board is %d lines high, want 30", got)
+	}
+	if !strings.Contains(m.View(), "more") {
+		t.Error("board does not tell about the clipped departures")
+	}
+}
+
+func TestValidationBlocksSearch(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "bern")
+	m = press(t, m, tea.KeyEnter)
+
+	if m.searched {
+		t.Error("searched with identical stations")
+	}
+	if !strings.Contains(m.View(), "Departure and arrival are the same station.") {
+		t.Error("view is missing the field error")
+	}
+}
+
+func TestFavoriteErrorKeepsResults(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Zürich")
+	m = press(t, m, tea.KeyEnter)
+	breakDataDir(t)
+
+	m = press(t, m, tea.KeyCtrlS, tea.KeyEnter)
+	view := m.View()
+	if !strings.Contains(view, "Could not save the favorite") {
+		t.Errorf("view is missing the notice:\n%s", view)
+	}
+	if !strings.Contains(view, "St. Gallen") {
+		t.Errorf("the notice hides the results:\n%s", view)
+	}
+
+	m = update(t, m, noticeTickMsg{id: m.noticeID})
+	if strings.Contains(m.View(), "Could not save the favorite") {
+		t.Error("the notice did not go away")
+	}
+}
+
+func TestLoadErrorsAreJoined(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dir := filepath.Join(os.Getenv("XDG_DATA_HOME"), "sbb-tui")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"favorites.json", "history.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := config.Default()
+	cfg.Cache.Enabled = false
+	m := InitialModel(cfg, fixtures.New())
+	for _, want := range []string{"Could not load favorites", "Could not load the search history"} {
+		if !strings.Contains(m.errorMsg, want) {
+			t.Errorf("error %q is missing %q", m.errorMsg, want)
+		}
+	}
+}
+
+func TestHistoryErrorKeepsResults(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+	breakDataDir(t)
+
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Zürich")
+	m = press(t, m, tea.KeyEnter)
+
+	if len(m.connections) != 4 || m.errorMsg != "" {
+		t.Fatalf("got %d connections and error %q, want 4 and none", len(m.connections), m.errorMsg)
+	}
+	if !strings.Contains(m.View(), "Could not save the search history") {
+		t.Error("view is missing the notice")
+	}
+}
+
+func TestDetailFitsTheScreen(t *testing.T) {
+	sizes := []struct{ width, height int }{{120, 30}, {110, 30}, {120, 26}, {90, 30}, {80, 14}}
+	for _, size := range sizes {
+		m := newGoldenModel(t, fixtureConnections(t), size.width, size.height)
+		m.resultIndex = 1
+		m.detailOpen = true
+		m.toggleStops()
+
+		if got := lipgloss.Height(m.View()); got != size.height {
+			t.Errorf("%dx%d: detail view is %d lines high", size.width, size.height, got)
+		}
+	}
+}
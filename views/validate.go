@@ -0,0 +1,181 @@
+package views
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"sbb-tui/models"
+	"sbb-tui/utils"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fieldError is a problem with one header field. Warnings are shown the
+// same way but do not keep the search from running.
+type fieldError struct {
+	id      string
+	msg     string
+	warning bool
+}
+
+var (
+	// Dates looking like a calendar day, to explain why they are rejected
+	isoDayPattern   = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	swissDayPattern = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.?(\d{2}|\d{4})?$`)
+)
+
+// validateInputs checks every header field, reporting at most one problem
+// per field.
+func (m model) validateInputs(now time.Time) []fieldError {
+	var errs []fieldError
+	add := func(id, msg string, warning bool) {
+		if _, ok := findFieldError(errs, id); !ok {
+			errs = append(errs, fieldError{id: id, msg: msg, warning: warning})
+		}
+	}
+
+	from := strings.TrimSpace(m.inputs[0].Value())
+	to := strings.TrimSpace(m.inputs[1].Value())
+	if m.mode == ModeBoard {
+		if from == "" {
+			add("station", "Please enter a station.", false)
+		}
+	} else {
+		if from == "" {
+			add("from", "Please enter a departure station.", false)
+		}
+		if to == "" {
+			add("to", "Please enter an arrival station.", false)
+		}
+		if from != "" && strings.EqualFold(from, to) {
+			add("to", "Departure and arrival are the same station.", false)
+		}
+		if len(m.viaStations()) > models.MaxVia {
+			add("via", fmt.Sprintf("Please enter at most %d via stations.", models.MaxVia), false)
+		}
+	}
+
+	date, err := utils.ParseDate(m.inputs[2].Value(), now)
+	if err != nil {
+		add("date", dateProblem(strings.TrimSpace(m.inputs[2].Value()), now), false)
+	} else if date.HasDate {
+		start, end := utils.TimetablePeriod(now)
+		if date.Date.Before(start) || date.Date.After(end) {
+			add("date", fmt.Sprintf("Outside the timetable %s–%s, results may be incomplete.",
+				start.Format("02.01.2006"), end.Format("02.01.2006")), true)
+		}
+	}
+	if _, err := utils.ParseWhen(m.inputs[3].Value(), now); err != nil {
+		add("time", "Please enter a valid time, e.g. 18:30, now, +45m or in 2h.", false)
+	}
+
+	if val := m.inputs[5].Value(); val != "" {
+		if limit, err := strconv.Atoi(val); err != nil || limit < 1 || limit > models.MaxLimit {
+			add("limit", fmt.Sprintf("Please enter a limit between 1 and %d.", models.MaxLimit), false)
+		}
+	}
+
+	return errs
+}
+
+// dateProblem explains why a date was rejected, pointing out days that do
+// not exist in the calendar.
+func dateProblem(s string, now time.Time) string {
+	var y, mo, d int
+	if p := isoDayPattern.FindStringSubmatch(s); p != nil {
+		y, _ = strconv.Atoi(p[1])
+		mo, _ = strconv.Atoi(p[2])
+		d, _ = strconv.Atoi(p[3])
+	} else if p := swissDayPattern.FindStringSubmatch(s); p != nil {
+		d, _ = strconv.Atoi(p[1])
+		mo, _ = strconv.Atoi(p[2])
+		y = now.Year()
+		if p[3] != "" {
+			y, _ = strconv.Atoi(p[3])
+			if len(p[3]) == 2 {
+				y += 2000
+			}
+		}
+	} else {
+		return "Please enter a valid date, e.g. 2025-10-16, 16.10., tomorrow or fri."
+	}
+
+	if mo < 1 || mo > 12 {
+		return fmt.Sprintf("There is no month %d.", mo)
+	}
+	month := time.Month(mo)
+	days := time.Date(y, month+1, 0, 0, 0, 0, 0, now.Location()).Day()
+	if d < 1 {
+		return fmt.Sprintf("There is no day %d.", d)
+	}
+	if d > days {
+		return fmt.Sprintf("%s %d has only %d days.", month, y, days)
+	}
+	return "Please enter a valid date, e.g. 2025-10-16, 16.10., tomorrow or fri."
+}
+
+func blocking(errs []fieldError) bool {
+	for _, e := range errs {
+		if !e.warning {
+			return true
+		}
+	}
+	return false
+}
+
+func findFieldError(errs []fieldError, id string) (fieldError, bool) {
+	for _, e := range errs {
+		if e.id == id {
+			return e, true
+		}
+	}
+	return fieldError{}, false
+}
+
+func (m model) fieldError(id string) (fieldError, bool) {
+	return findFieldError(m.fieldErrors, id)
+}
+
+// revalidate follows the edits of flagged fields: fixed errors go away
+// without new ones popping up while typing, warnings are always current.
+func (m *model) revalidate() {
+	var errs []fieldError
+	for _, e := range m.validateInputs(time.Now()) {
+		if _, flagged := m.fieldError(e.id); flagged || e.warning {
+			errs = append(errs, e)
+		}
+	}
+	m.fieldErrors = errs
+}
+
+// fieldMessage is the message shown under its header item, preferring the
+// focused field.
+func (m model) fieldMessage() (string, string) {
+	if len(m.fieldErrors) == 0 {
+		return "", ""
+	}
+
+	e := m.fieldErrors[0]
+	if m.tabIndex < len(m.headerOrder) {
+		if focused, ok := m.fieldError(m.headerOrder[m.tabIndex].id); ok {
+			e = focused
+		}
+	}
+
+	text := noStyle.Foreground(sbbRed).Bold(true).Render(e.msg)
+	if e.warning {
+		text = noStyle.Foreground(sbbRed).Render(wrnIcon) + " " + e.msg
+	}
+	return suggestionStyle.Render(text), e.id
+}
+
+// fieldStyle marks a header item holding an error.
+func (m model) fieldStyle(id string, style lipgloss.Style) lipgloss.Style {
+	if e, ok := m.fieldError(id); ok && !e.warning {
+		return style.Border(errorBorder).BorderForeground(sbbRed)
+	}
+	return style
+}
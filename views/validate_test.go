@@ -0,0 +1,105 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// A Friday in the timetable period 2025-12-14 to 2026-12-12
+var validateNow = time.Date(2026, time.October, 16, 10, 0, 0, 0, time.Local)
+
+func TestBoardStationRequired(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+	m.toggleMode()
+	m.inputs[0].SetValue(" ")
+
+	errs := m.validateInputs(time.Now())
+	if _, ok := findFieldError(errs, "station"); !ok {
+		t.Errorf("field errors = %v, want one on the station", errs)
+	}
+}
+
+func TestDateFieldRejectsTimes(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+	for _, date := range []string{"12", "2025", "7:30"} {
+		m.inputs[2].SetValue(date)
+		if _, ok := findFieldError(m.validateInputs(time.Now()), "date"); !ok {
+			t.Errorf("date %q was accepted", date)
+		}
+	}
+}
+
+func TestDateProblems(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2025-02-31", "February 2025 has only 28 days."},
+		{"2028-02-30", "February 2028 has only 29 days."},
+		{"31.04.", "April 2026 has only 30 days."},
+		{"00.10.", "There is no day 0."},
+		{"2026-10-00", "There is no day 0."},
+		{"16.13.", "There is no month 13."},
+		{"someday", "Please enter a valid date, e.g. 2025-10-16, 16.10., tomorrow or fri."},
+	}
+
+	m := newTestModel(t, 160, 40)
+	for _, tt := range tests {
+		m.inputs[2].SetValue(tt.date)
+		errs := m.validateInputs(validateNow)
+		e, ok := findFieldError(errs, "date")
+		if !ok || e.msg != tt.want || e.warning {
+			t.Errorf("date %q: field error %+v, want %q", tt.date, e, tt.want)
+		}
+		if !blocking(errs) {
+			t.Errorf("date %q does not block the search", tt.date)
+		}
+	}
+}
+
+func TestLimitRange(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+	for _, limit := range []string{"0", "17", "99", "-1", "x"} {
+		m.inputs[5].SetValue(limit)
+		e, ok := findFieldError(m.validateInputs(validateNow), "limit")
+		if !ok || !strings.Contains(e.msg, "between 1 and 16") {
+			t.Errorf("limit %q: field error %+v, want the range", limit, e)
+		}
+	}
+	for _, limit := range []string{"", "1", "16"} {
+		m.inputs[5].SetValue(limit)
+		if e, ok := findFieldError(m.validateInputs(validateNow), "limit"); ok {
+			t.Errorf("limit %q was rejected: %s", limit, e.msg)
+		}
+	}
+}
+
+func TestTimetableWarningDoesNotBlock(t *testing.T) {
+	m := newTestModel(t, 160, 40)
+	m = typeText(t, m, "Bern")
+	m = press(t, m, tea.KeyTab)
+	m = typeText(t, m, "Zürich")
+	m.inputs[2].SetValue("2027-03-01")
+
+	errs := m.validateInputs(validateNow)
+	e, ok := findFieldError(errs, "date")
+	if !ok || !e.warning || !strings.HasPrefix(e.msg, "Outside the timetable 14.12.2025") {
+		t.Fatalf("field error %+v, want a timetable warning", e)
+	}
+	if blocking(errs) {
+		t.Error("the warning blocks the search")
+	}
+
+	// The search runs with the warning shown, the fixtures answer any date
+	m.inputs[2].SetValue(time.Now().AddDate(2, 0, 0).Format("2006-01-02"))
+	m = press(t, m, tea.KeyEnter)
+	if !m.searched || len(m.connections) == 0 {
+		t.Errorf("searched %v with %d connections, want the search run", m.searched, len(m.connections))
+	}
+	if _, ok := m.fieldError("date"); !ok {
+		t.Error("the warning is not shown")
+	}
+}
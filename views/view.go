@@ -2,17 +2,24 @@
 package views
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"sbb-tui/api"
+	"sbb-tui/config"
 	"sbb-tui/models"
+	"sbb-tui/notify"
+	"sbb-tui/store"
 	"sbb-tui/utils"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 const (
@@ -21,15 +28,24 @@ const (
 	KindButton
 )
 
+const (
+	// Top-level modes
+	ModeConnections int = iota
+	ModeBoard
+)
+
 const (
 	// Layout dimensions
 	borderSize     = 2
 	hdrHeight      = 3
-	hdrMinWidth    = 82
+	hdrMinWidth    = 108
 	hdrElmtPadd    = 2
+	hdrInputFrame  = 7
+	hdrButtonWidth = 5
 	rsltMrgn       = 1
 	smplConnHeight = 9
 	smplConnMrgn   = 3
+	scrollbarWidth = 1
 
 	stopsLineFixedWidth = (borderSize * 2) + (smplConnMrgn * 2) + (2+5)*2 + 6
 	stopsLineMinWidth   = 10
@@ -38,20 +54,33 @@ const (
 	fullConnPaddV = 1
 )
 
-const (
-	// Icons
+var (
+	// Rail
 	filledDot = "●"
 	hollowDot = "○"
 	horzLine  = "─"
 	vertLine  = "│"
+	arrow     = "→"
 
-	arrIcon  = "󰗔"
-	dptIcon  = ""
-	pltIcon  = "󱀓"
-	srchIcon = ""
-	swpIcon  = ""
-	vhcIcon  = ""
-	wlkIcon  = ""
+	// Marks
+	separator = "·"
+	selMarker = "▌"
+)
+
+var (
+	// Icons
+	arrIcon   = "󰗔"
+	brdIcon   = "󰅐"
+	cnnIcon   = "󰑪"
+	dptIcon   = ""
+	pltIcon   = "󱀓"
+	prmptIcon = ""
+	srchIcon  = ""
+	swpIcon   = ""
+	vhcIcon   = ""
+	wlkIcon   = ""
+	wrnIcon   = ""
+	wtchIcon  = ""
 )
 
 var (
@@ -72,32 +101,65 @@ var (
 	sbbGreen      = lipgloss.Color("#3A7446")
 )
 
+var (
+	// Borders
+	boxBorder   = lipgloss.RoundedBorder()
+	errorBorder = lipgloss.ThickBorder()
+)
+
 var (
 	// Styles
 	noStyle = lipgloss.NewStyle()
 
+	focusedStyle         lipgloss.Style
+	blurredStyle         lipgloss.Style
+	detailedResultStyle  lipgloss.Style
+	titleStyle           lipgloss.Style
+	suggestionStyle      lipgloss.Style
+	vehicleIconStyle     lipgloss.Style
+	vehicleCategoryStyle lipgloss.Style
+	companyStyle         lipgloss.Style
+	changedStyle         lipgloss.Style
+	warningStyle         lipgloss.Style
+)
+
+func init() { buildStyles() }
+
+// buildStyles derives the styles from the current colors.
+func buildStyles() {
 	focusedStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(sbbRed).
-			Padding(0, 1)
+		Border(boxBorder).
+		BorderForeground(sbbRed).
+		Padding(0, 1)
 
 	blurredStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(sbbMidGray).
-			Padding(0, 1)
+		Border(boxBorder).
+		BorderForeground(sbbMidGray).
+		Padding(0, 1)
 
 	detailedResultStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(sbbRed).
-				Padding(fullConnPaddV, fullConnPaddH)
+		Border(boxBorder).
+		BorderForeground(sbbRed).
+		Padding(fullConnPaddV, fullConnPaddH)
 
 	titleStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(sbbRed).
-			Bold(true).
-			Foreground(sbbWhite).
-			Background(sbbRed)
-)
+		Border(boxBorder).
+		BorderForeground(sbbRed).
+		Bold(true).
+		Foreground(sbbWhite).
+		Background(sbbRed)
+
+	suggestionStyle = lipgloss.NewStyle().
+		Border(boxBorder).
+		BorderForeground(sbbRed).
+		Padding(0, 1)
+
+	vehicleIconStyle = noStyle.Background(sbbBlue).Foreground(sbbWhite)
+	vehicleCategoryStyle = noStyle.Background(sbbRed).Foreground(sbbWhite).Bold(true)
+	companyStyle = noStyle.Background(sbbWhite).Foreground(sbbBlack)
+	changedStyle = noStyle.Background(sbbDarkRed).Foreground(sbbWhite).Bold(true)
+	warningStyle = noStyle.Background(sbbRed).Foreground(sbbWhite).Bold(true)
+}
 
 type focusable struct {
 	kind  int
@@ -106,36 +168,118 @@ type focusable struct {
 }
 
 type DataMsg struct {
+	id          int
 	connections []models.Connection
 	err         error
 }
 
 type model struct {
-	width, height int
-	tabIndex      int
-	resultIndex   int
-	headerOrder   []focusable
-	inputs        []textinput.Model
-	isArrivalTime bool
-	connections   []models.Connection
-	loading       bool
-	errorMsg      string
-	searched      bool
-}
-
-func InitialModel() model {
+	width, height   int
+	tabIndex        int
+	resultIndex     int
+	resultOffset    int
+	headerOrder     []focusable
+	keys            keyMap
+	provider        api.Provider
+	inputs          []textinput.Model
+	isArrivalTime   bool
+	connections     []models.Connection
+	loading         bool
+	searchID        int
+	searchCtx       context.Context
+	cancelSearch    context.CancelFunc
+	errorMsg        string
+	notice          string
+	noticeID        int
+	searched        bool
+	mode            int
+	transportations []string
+	transportMenu   bool
+	transportIndex  int
+	picker          int
+	pickerValue     time.Time
+	detailOpen      bool
+	fieldErrors     []fieldError
+	defaultLimit    int
+	favorites       *store.Favorites
+	favPicker       bool
+	favIndex        int
+	favNaming       bool
+	favName         textinput.Model
+	history         *store.History
+	histSearch      bool
+	histQuery       textinput.Model
+	histMatches     []store.HistoryEntry
+	histIndex       int
+	board           []models.StationboardEntry
+	query           models.Input
+	pageFirst       int
+	pageLast        int
+	noEarlier       bool
+	noLater         bool
+	paging          bool
+	pageStatus      string
+	cache           *store.Cache
+	cachedAt        time.Time
+	refreshing      bool
+	offline         bool
+	refreshInterval time.Duration
+	changes         map[string]connectionChange
+	hideCrowded     bool
+	hiddenCrowded   int
+	stopsKey        string
+	stopsSection    int
+	watched         *watch
+	watchID         int
+	watchInterval   time.Duration
+	delayThreshold  int
+	notifier        notify.Notifier
+	suggestions     []models.Location
+	suggestIndex    int
+	suggestField    int
+	suggestID       int
+}
+
+// InitialModel builds the interface on top of the provider answering its
+// timetable queries.
+func InitialModel(cfg config.Config, provider api.Provider) model {
+	applyTheme(cfg.Theme)
+	applyIcons(cfg.IconGlyphs())
+	applyBorders(cfg.IconSet())
+
 	// Define input prompts
 	m := model{
-		headerOrder: []focusable{
-			{KindInput, "from", 0},
-			{KindInput, "to", 1},
-			{KindButton, "swap", -1},
-			{KindButton, "isArrivalTime", -1},
-			{KindInput, "date", 2},
-			{KindInput, "time", 3},
-			{KindButton, "search", -1},
-		},
-		inputs: make([]textinput.Model, 4),
+		headerOrder:     connectionsHeader(),
+		tabIndex:        1,
+		keys:            newKeyMap(cfg.Keys),
+		provider:        provider,
+		inputs:          make([]textinput.Model, 6),
+		defaultLimit:    cfg.Defaults.Limit,
+		refreshInterval: cfg.Refresh.Interval,
+		hideCrowded:     cfg.Defaults.HideCrowded,
+		watchInterval:   cfg.Watch.Interval,
+		delayThreshold:  cfg.Watch.DelayThreshold,
+		notifier:        newNotifier(cfg.Watch),
+	}
+
+	var loadErrors []string
+	favorites, err := store.LoadFavorites()
+	if err != nil {
+		loadErrors = append(loadErrors, "Could not load favorites: "+err.Error()+".")
+	}
+	m.favorites = favorites
+
+	history, err := store.LoadHistory()
+	if err != nil {
+		loadErrors = append(loadErrors, "Could not load the search history: "+err.Error()+".")
+	}
+	m.history = history
+	m.errorMsg = strings.Join(loadErrors, " ")
+
+	if cfg.Cache.Enabled {
+		if cache, err := store.OpenCache(cfg.Cache.TTL, cfg.Cache.MaxEntries); err == nil {
+			m.cache = cache
+		}
 	}
 
 	now := time.Now()
@@ -147,27 +291,65 @@ func InitialModel() model {
 		switch i {
 		case 0:
 			t.Placeholder = "From"
-			t.Prompt = " "
+			t.SetValue(cfg.Defaults.From)
+			t.Prompt = prmptIcon + " "
 			t.Focus()
 		case 1:
 			t.Placeholder = "To"
-			t.Prompt = " "
+			t.Prompt = prmptIcon + " "
 		case 2:
 			t.Placeholder = now.Format("2006-01-02")
-			t.Prompt = " "
+			t.Prompt = prmptIcon + " "
 			t.Width = 12
-			t.CharLimit = 10
+			t.CharLimit = 20
 		case 3:
 			t.Placeholder = now.Format("15:04")
-			t.Prompt = " "
+			t.Prompt = prmptIcon + " "
 			t.Width = 7
-			t.CharLimit = 5
+			t.CharLimit = 20
+		case 4:
+			t.Placeholder = "Via"
+			t.Prompt = prmptIcon + " "
+		case 5:
+			t.Placeholder = "#"
+			t.Prompt = prmptIcon + " "
+			t.Width = 2
+			t.CharLimit = 2
 		}
 		m.inputs[i] = t
 	}
 	return m
 }
 
+func connectionsHeader() []focusable {
+	return []focusable{
+		{KindButton, "mode", -1},
+		{KindInput, "from", 0},
+		{KindInput, "to", 1},
+		{KindButton, "swap", -1},
+		{KindInput, "via", 4},
+		{KindButton, "isArrivalTime", -1},
+		{KindInput, "date", 2},
+		{KindInput, "time", 3},
+		{KindButton, "transportations", -1},
+		{KindInput, "limit", 5},
+		{KindButton, "search", -1},
+	}
+}
+
+func boardHeader() []focusable {
+	return []focusable{
+		{KindButton, "mode", -1},
+		{KindInput, "station", 0},
+		{KindButton, "isArrivalTime", -1},
+		{KindInput, "date", 2},
+		{KindInput, "time", 3},
+		{KindButton, "transportations", -1},
+		{KindInput, "limit", 5},
+		{KindButton, "search", -1},
+	}
+}
+
 func (m model) Init() tea.Cmd { return textinput.Blink }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -176,33 +358,74 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
-		inputWidth := (m.width - hdrElmtPadd - hdrMinWidth) / 2
-		m.inputs[0].Width = inputWidth
-		m.inputs[1].Width = inputWidth
+		m.resizeInputs()
+		m.scrollResults()
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc":
+		if ok, cmd := m.updateHistory(msg); ok {
+			return m, cmd
+		}
+		if ok, cmd := m.updateFavorites(msg); ok {
+			return m, cmd
+		}
+		if m.updateSuggestions(msg) {
+			return m, nil
+		}
+		if m.updateTransportMenu(msg) {
+			return m, nil
+		}
+		if m.updatePicker(msg) {
+			return m, nil
+		}
+
+		switch {
+		case (m.loading || m.refreshing || m.paging) && key.Matches(msg, m.keys.Cancel):
+			wasLoading := m.loading
+			m.abortSearch()
+			if wasLoading {
+				m.errorMsg = "Search cancelled."
+			}
+			// The abort drops the pending refresh tick with the old search,
+			// the results still shown need a new one
+			if len(m.connections) > 0 {
+				return m, m.scheduleRefresh()
+			}
+		case m.detailOpen && m.stacked() && key.Matches(msg, m.keys.Close):
+			m.detailOpen = false
+
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
-		case "q":
+		case key.Matches(msg, m.keys.SoftQuit):
 			active := m.headerOrder[m.tabIndex]
 			if active.kind == KindButton {
 				return m, tea.Quit
 			}
 
-		case "enter":
-			if err := m.validateInputs(); err != "" {
-				m.errorMsg = err
+		case key.Matches(msg, m.keys.Search):
+			if m.showDetail() {
+				m.detailOpen = true
 				return m, nil
 			}
-			m.loading = true
-			m.connections = nil
-			m.errorMsg = ""
-			m.searched = true
-			return m, m.searchCmd()
+			return m, m.startSearch()
 
-		case " ":
+		case key.Matches(msg, m.keys.Watch):
+			return m, m.toggleWatch()
+
+		case key.Matches(msg, m.keys.Stops):
+			m.toggleStops()
+
+		case key.Matches(msg, m.keys.Picker):
+			m.clearSuggestions()
+			m.openPicker()
+
+		case key.Matches(msg, m.keys.HideCrowded):
+			m.hideCrowded = !m.hideCrowded
+			if m.searched && m.mode == ModeConnections {
+				return m, m.startSearch()
+			}
+
+		case key.Matches(msg, m.keys.Toggle):
 			active := m.headerOrder[m.tabIndex]
 			switch active.id {
 			case "swap":
@@ -211,20 +434,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.inputs[1].SetValue(tmp)
 			case "isArrivalTime":
 				m.isArrivalTime = !m.isArrivalTime
+			case "mode":
+				m.toggleMode()
+			case "transportations":
+				m.transportMenu = true
 			case "search":
-				if err := m.validateInputs(); err != "" {
-					m.errorMsg = err
-					return m, nil
-				}
-				m.loading = true
-				m.connections = nil
-				m.errorMsg = ""
-				m.searched = true
-				return m, m.searchCmd()
+				return m, m.startSearch()
 			}
 
-		case "tab", "shift+tab":
-			if msg.String() == "shift+tab" {
+		case key.Matches(msg, m.keys.Next, m.keys.Prev):
+			m.clearSuggestions()
+			if key.Matches(msg, m.keys.Prev) {
 				m.tabIndex--
 			} else {
 				m.tabIndex++
@@ -249,35 +469,138 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, tea.Batch(cmds...)
 
-		case "up":
+		case key.Matches(msg, m.keys.Up):
 			if len(m.connections) > 0 && m.resultIndex > 0 {
-				m.resultIndex--
+				m.moveResult(-1)
+			} else if len(m.connections) > 0 {
+				return m, m.fetchPage(m.pageFirst - 1)
 			}
-		case "down":
+		case key.Matches(msg, m.keys.Down):
 			if len(m.connections) > 0 && m.resultIndex < len(m.connections)-1 {
-				m.resultIndex++
+				m.moveResult(1)
+			} else if len(m.connections) > 0 {
+				return m, m.fetchPage(m.pageLast + 1)
+			}
+		case key.Matches(msg, m.keys.PageUp):
+			m.moveResult(-m.maxVisibleConnections())
+		case key.Matches(msg, m.keys.PageDown):
+			m.moveResult(m.maxVisibleConnections())
+		case key.Matches(msg, m.keys.Home, m.keys.End):
+			// Inputs keep home/end for their cursor
+			if m.headerOrder[m.tabIndex].kind == KindButton {
+				if key.Matches(msg, m.keys.Home) {
+					m.moveResult(-len(m.connections))
+				} else {
+					m.moveResult(len(m.connections))
+				}
 			}
 		}
 
 	case DataMsg:
+		if msg.id != m.searchID {
+			return m, nil
+		}
 		m.loading = false
+		m.refreshing = false
 		if msg.err != nil {
-			m.errorMsg = "Failed to fetch connections. Check your internet connection."
+			if m.keepCached(msg.err) {
+				return m, m.scheduleRefresh()
+			}
+			m.errorMsg = fetchErrorMessage(msg.err, "connections")
 			return m, nil
 		}
-		m.connections = msg.connections
-		m.resultIndex = 0
+		m.cacheResults(msg.connections)
+		m.hiddenCrowded = 0
+		connections := m.filterCrowded(msg.connections)
+		if m.cachedAt.IsZero() {
+			m.connections = connections
+			m.resultIndex = 0
+			m.resultOffset = 0
+		} else {
+			m.replaceConnections(connections)
+			m.cachedAt = time.Time{}
+			m.offline = false
+		}
+		var cmd tea.Cmd
+		if len(msg.connections) > 0 {
+			cmd = m.recordSearch()
+		}
 		if len(m.connections) == 0 {
 			m.errorMsg = "No connections found for the specified route."
+			if m.hiddenCrowded > 0 {
+				m.errorMsg = "Every connection found is crowded in 2nd class."
+			}
+		}
+		return m, tea.Batch(cmd, m.scheduleRefresh())
+
+	case PageMsg:
+		m.mergePage(msg)
+		return m, nil
+
+	case refreshTickMsg:
+		return m, m.handleRefreshTick(msg)
+
+	case RefreshMsg:
+		return m, m.applyRefresh(msg)
+
+	case watchTickMsg:
+		return m, m.handleWatchTick(msg)
+
+	case WatchMsg:
+		return m, m.updateWatch(msg)
+
+	case noticeTickMsg:
+		m.clearNotice(msg)
+		return m, nil
+
+	case suggestTickMsg:
+		if msg.id != m.suggestID {
+			return m, nil
+		}
+		return m, m.suggestCmd(msg)
+
+	case LocationsMsg:
+		m.setSuggestions(msg)
+		return m, nil
+
+	case BoardMsg:
+		if msg.id != m.searchID {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.errorMsg = fetchErrorMessage(msg.err, "the departure board")
+			return m, nil
+		}
+		m.board = msg.entries
+		if len(m.board) == 0 {
+			m.errorMsg = "No departures found for the specified station."
 		}
 		return m, nil
 	}
 
+	active := m.headerOrder[m.tabIndex]
+	prev := ""
+	if active.kind == KindInput {
+		prev = m.inputs[active.index].Value()
+	}
+
 	cmd := m.updateInputs(msg)
+
+	if active.kind == KindInput && m.inputs[active.index].Value() != prev {
+		m.revalidate()
+		if isStationField(active.index) {
+			cmd = tea.Batch(cmd, m.scheduleSuggestions(active.index))
+		}
+	}
 	return m, cmd
 }
 
 func (m model) View() string {
+	if m.tooSmall() {
+		return m.renderTooSmall()
+	}
+
 	header := m.renderHeader()
 	results := lipgloss.JoinHorizontal(lipgloss.Top,
 		noStyle.
@@ -287,17 +610,88 @@ func (m model) View() string {
 			Height(m.resultsHeight()).
 			Render(m.renderDetailedResult()),
 	)
+	if m.stacked() {
+		results = noStyle.Height(m.resultsHeight()).Render(m.renderResults())
+		if m.detailOpen {
+			results = noStyle.Height(m.resultsHeight()).Render(m.renderDetailedResult())
+		}
+	}
+	if m.mode == ModeBoard {
+		results = noStyle.Height(m.resultsHeight()).Render(m.renderBoard())
+	}
 
-	return lipgloss.JoinVertical(lipgloss.Left,
+	view := lipgloss.JoinVertical(lipgloss.Left,
 		header,
 		noStyle.
-			Border(lipgloss.RoundedBorder()).
+			Border(boxBorder).
 			BorderForeground(sbbDarkRed).
 			Width(m.contentWidth()).
 			Height(m.resultsHeight()).
 			Padding(0, rsltMrgn).
 			Render(results),
 	)
+
+	if m.notice != "" {
+		view = placeOverlay(borderSize/2+rsltMrgn, m.headerHeight()+m.resultsHeight(), m.renderNotice(), view)
+	}
+
+	// Field messages give way to the menus opened on top of them
+	if msg, id := m.fieldMessage(); msg != "" && m.picker == pickerNone {
+		x, y := m.headerAnchor(id)
+		if preview, previewID := m.whenPreview(); previewID == id {
+			y += lipgloss.Height(preview)
+		}
+		view = placeOverlay(x, y, msg, view)
+	}
+
+	if m.suggestionsVisible() {
+		x, y, width := m.suggestionsAnchor()
+		view = placeOverlay(x, y, m.renderSuggestions(width), view)
+	}
+
+	if m.picker != pickerNone {
+		x, y := m.headerAnchor(m.headerOrder[m.tabIndex].id)
+		view = placeOverlay(x, y, m.renderPicker(), view)
+	} else if preview, id := m.whenPreview(); preview != "" {
+		x, y := m.headerAnchor(id)
+		view = placeOverlay(x, y, preview, view)
+	}
+
+	if m.transportMenu {
+		x, y := m.headerAnchor("transportations")
+		view = placeOverlay(x, y, m.renderTransportMenu(), view)
+	}
+
+	if m.favPicker {
+		view = m.centerOverlay(m.renderFavorites(), view)
+	}
+	if m.favNaming {
+		view = m.centerOverlay(m.renderFavoriteNaming(), view)
+	}
+	if m.histSearch {
+		view = m.centerOverlay(m.renderHistory(), view)
+	}
+
+	return view
+}
+
+func (m *model) resizeInputs() {
+	inputWidth := (m.width - hdrElmtPadd - hdrMinWidth) / 3
+	if m.headerWrapped() {
+		// The stations have the first row to themselves
+		inputWidth = (m.width - hdrElmtPadd - hdrButtonWidth*2 - hdrInputFrame*3) / 3
+	}
+	m.inputs[0].Width = inputWidth
+	m.inputs[1].Width = inputWidth
+	m.inputs[4].Width = inputWidth
+
+	m.inputs[5].Placeholder = fmt.Sprint(m.defaultConnectionsLimit())
+
+	if m.mode == ModeBoard {
+		// The station input takes over the space of the To and Via inputs and swap button
+		m.inputs[0].Width = inputWidth*3 + hdrInputFrame*2 + hdrButtonWidth
+		m.inputs[5].Placeholder = fmt.Sprint(m.maxVisibleBoardEntries())
+	}
 }
 
 func (m model) contentWidth() int {
@@ -305,11 +699,19 @@ func (m model) contentWidth() int {
 }
 
 func (m model) resultsHeight() int {
-	return max(m.height-hdrHeight-hdrElmtPadd, 0)
+	return max(m.height-m.headerHeight()-hdrElmtPadd, 0)
 }
 
 func (m model) maxVisibleConnections() int {
-	return max(m.resultsHeight()/smplConnHeight, 1)
+	return max(m.resultsHeight()/m.connHeight(), 1)
+}
+
+// defaultConnectionsLimit is used when the limit input is left empty.
+func (m model) defaultConnectionsLimit() int {
+	if m.defaultLimit > 0 {
+		return m.defaultLimit
+	}
+	return m.maxVisibleConnections()
 }
 
 func (m *model) updateInputs(msg tea.Msg) tea.Cmd {
@@ -320,95 +722,26 @@ func (m *model) updateInputs(msg tea.Msg) tea.Cmd {
 		// Check key input in input fields
 		switch m.headerOrder[m.tabIndex].id {
 		case "date":
+			// Separators for ISO dates typed as digits, anything else is left
+			// to the natural language parser
 			t := &m.inputs[2]
-			s := msg.String()
-			val := t.Value()
-
-			if msg.Type == tea.KeyBackspace {
-				if len(val) == 5 || len(val) == 8 {
-					t.SetValue(val[:len(val)-1])
-					return nil
-				}
-			}
-
-			if len(s) == 1 && s >= "0" && s <= "9" {
-				switch len(val) {
-				case 0:
-					if s > "2" {
-						return nil
-					}
-				case 1:
-					if val == "2" && s > "9" {
-						return nil
-					}
-				case 2, 3:
-				case 4:
-					t.SetValue(val + "-" + s)
-					t.SetCursor(len(val) + 2)
-					return nil
-				case 5:
-					if val[5] == '0' && s == "0" {
-						return nil
-					}
-					if val[5] == '1' && s > "2" {
-						return nil
-					}
-				case 6:
-				case 7:
-					t.SetValue(val + "-" + s)
-					t.SetCursor(len(val) + 2)
-					return nil
-				case 8:
-					if val[8] == '0' && s == "0" {
-						return nil
-					}
-					if val[8] == '3' && s > "1" {
-						return nil
-					}
-				case 9:
-				default:
-					return nil
-				}
-			} else if msg.Type == tea.KeyRunes {
+			if isDigit(msg) && isoDatePrefix.MatchString(t.Value()) && t.Position() == len(t.Value()) {
+				t.SetValue(t.Value() + "-" + msg.String())
+				t.CursorEnd()
 				return nil
 			}
 
-		case "time":
-			t := &m.inputs[3]
+		case "limit":
 			s := msg.String()
-			val := t.Value()
-
-			if msg.Type == tea.KeyBackspace && len(val) == 3 {
-				t.SetValue(val[:1])
+			if msg.Type == tea.KeyRunes && (len(s) != 1 || s < "0" || s > "9") {
 				return nil
 			}
 
-			// Only process numeric runes for the following logic
-			if len(s) == 1 && s >= "0" && s <= "9" {
-				switch len(val) {
-				// Logic for each digit
-				case 0:
-					if s > "2" {
-						return nil
-					}
-				case 1:
-					if val == "2" && s > "3" {
-						return nil
-					}
-				// Add `:` when typing third digit
-				case 2:
-					t.SetValue(val + ":" + s)
-					t.SetCursor(5)
-					return nil
-				case 3:
-					if s > "5" {
-						return nil
-					}
-				case 4:
-				default:
-					return nil
-				}
-			} else if msg.Type == tea.KeyRunes {
+		case "time":
+			t := &m.inputs[3]
+			if isDigit(msg) && clockPrefix.MatchString(t.Value()) && t.Position() == len(t.Value()) {
+				t.SetValue(t.Value() + ":" + msg.String())
+				t.CursorEnd()
 				return nil
 			}
 		}
@@ -420,40 +753,135 @@ func (m *model) updateInputs(msg tea.Msg) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
-func (m model) validateInputs() string {
-	if m.inputs[0].Value() == "" {
-		return "Please enter a departure station."
+// searchInput collects the header values into an API query, leaving out
+// what validateInputs would reject.
+func (m model) searchInput() models.Input {
+	input := models.Input{
+		From:            strings.TrimSpace(m.inputs[0].Value()),
+		To:              strings.TrimSpace(m.inputs[1].Value()),
+		IsArrivalTime:   m.isArrivalTime,
+		Transportations: m.transportations,
+		Limit:           m.defaultConnectionsLimit(),
 	}
-	if m.inputs[1].Value() == "" {
-		return "Please enter an arrival station."
+
+	if m.mode == ModeBoard {
+		input.To = ""
+		input.Limit = m.maxVisibleBoardEntries()
+	} else {
+		input.Via = m.viaStations()
 	}
-	return ""
+
+	when, _ := m.when(time.Now())
+	if when.HasDate {
+		input.Date = when.Date
+	}
+	if when.HasTime {
+		input.Time = when.Time
+	}
+
+	if val := m.inputs[5].Value(); val != "" {
+		if limit, err := strconv.Atoi(val); err == nil && limit > 0 {
+			input.Limit = limit
+		}
+	}
+
+	return input
+}
+
+func (m model) viaStations() []string {
+	var stations []string
+	for via := range strings.SplitSeq(m.inputs[4].Value(), ",") {
+		if via = strings.TrimSpace(via); via != "" {
+			stations = append(stations, via)
+		}
+	}
+	return stations
+}
+
+func (m *model) startSearch() tea.Cmd {
+	m.fieldErrors = m.validateInputs(time.Now())
+	if blocking(m.fieldErrors) {
+		return nil
+	}
+	m.query = m.searchInput()
+	m.detailOpen = false
+	m.newSearch()
+	m.pageFirst, m.pageLast = 0, 0
+	m.noEarlier, m.noLater = false, false
+	m.paging = false
+	m.pageStatus = ""
+	m.loading = true
+	m.connections = nil
+	m.board = nil
+	m.errorMsg = ""
+	m.searched = true
+	m.cachedAt = time.Time{}
+	m.offline = false
+	m.changes = nil
+	if m.showCached(false) {
+		m.loading = false
+		m.refreshing = true
+	}
+	m.clearSuggestions()
+	return m.searchCmd()
 }
 
 func (m model) searchCmd() tea.Cmd {
-	maxConnections := m.maxVisibleConnections()
+	if m.mode == ModeBoard {
+		return m.boardCmd()
+	}
+
+	provider, ctx, id, input := m.provider, m.searchCtx, m.searchID, m.query
 	return func() tea.Msg {
-		res, err := api.FetchConnections(
-			m.inputs[0].Value(),
-			m.inputs[1].Value(),
-			m.inputs[2].Value(),
-			m.inputs[3].Value(),
-			m.isArrivalTime,
-			maxConnections,
-		)
-		return DataMsg{connections: res, err: err}
+		res, err := provider.Connections(ctx, input)
+		return DataMsg{id: id, connections: res, err: err}
+	}
+}
+
+// newSearch aborts the running search and starts a new generation, replies
+// tagged with an older id are dropped on arrival.
+func (m *model) newSearch() {
+	if m.cancelSearch != nil {
+		m.cancelSearch()
+	}
+	m.searchID++
+	m.searchCtx, m.cancelSearch = context.WithCancel(context.Background())
+}
+
+// abortSearch cancels the running search and any page being fetched.
+func (m *model) abortSearch() {
+	m.newSearch()
+	m.loading = false
+	m.refreshing = false
+	m.paging = false
+	m.pageStatus = ""
+}
+
+func (m model) cancelHint() string {
+	k := firstKey(m.keys.Cancel)
+	if k == "" {
+		return ""
 	}
+	return noStyle.Foreground(sbbGray).Render("(" + k + " to cancel)")
 }
 
 func (m model) renderHeader() string {
-	var headerItems []string
-	for i := range m.headerOrder {
-		headerItems = append(headerItems, m.renderHeaderItem(i))
+	var rows []string
+	for _, row := range m.headerRows() {
+		var headerItems []string
+		for _, i := range row {
+			headerItems = append(headerItems, m.renderHeaderItem(i))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, headerItems...))
 	}
 
-	headerItems = append(headerItems, titleStyle.Render(" SBB TIMETABLES <+> "))
+	// The title closes the last row when there is room left
+	title := titleStyle.Render(" SBB TIMETABLES <+> ")
+	if last := len(rows) - 1; lipgloss.Width(rows[last]+title) <= m.width {
+		rows[last] = lipgloss.JoinHorizontal(lipgloss.Top, rows[last], title)
+	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, headerItems...)
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 func (m model) renderHeaderItem(idx int) string {
@@ -462,6 +890,7 @@ func (m model) renderHeaderItem(idx int) string {
 	if m.tabIndex == idx {
 		style = focusedStyle
 	}
+	style = m.fieldStyle(item.id, style)
 
 	if item.kind == KindInput {
 		return style.Render(m.inputs[item.index].View())
@@ -469,6 +898,12 @@ func (m model) renderHeaderItem(idx int) string {
 
 	icon := " "
 	switch item.id {
+	case "mode":
+		if m.mode == ModeBoard {
+			icon = brdIcon
+		} else {
+			icon = cnnIcon
+		}
 	case "swap":
 		icon = swpIcon
 	case "isArrivalTime":
@@ -477,6 +912,11 @@ func (m model) renderHeaderItem(idx int) string {
 		} else {
 			icon = dptIcon
 		}
+	case "transportations":
+		icon = vhcIcon
+		if len(m.transportations) > 0 {
+			icon = noStyle.Foreground(sbbRed).Render(vhcIcon)
+		}
 	case "search":
 		icon = srchIcon
 	}
@@ -484,12 +924,15 @@ func (m model) renderHeaderItem(idx int) string {
 }
 
 func (m model) resultBoxWidth() int {
+	if m.stacked() {
+		return m.width - borderSize*3 - scrollbarWidth
+	}
 	return max((m.width-smplConnMrgn)/2, rsltMrgn+stopsLineMinWidth+stopsLineFixedWidth)
 }
 
 func (m model) renderResults() string {
 	if m.loading {
-		return "\n  Searching connections..."
+		return "\n  Searching connections... " + m.cancelHint()
 	}
 
 	if m.errorMsg != "" {
@@ -506,11 +949,24 @@ func (m model) renderResults() string {
 	var boxes []string
 	boxWidth := m.resultBoxWidth()
 
-	for i, c := range m.connections {
-		boxes = append(boxes, m.renderSimpleConnection(c, i, boxWidth))
+	start, end := m.resultWindow()
+	for i := start; i < end; i++ {
+		if m.compact() {
+			boxes = append(boxes, m.renderCompactConnection(m.connections[i], i, boxWidth+borderSize))
+		} else {
+			boxes = append(boxes, m.renderSimpleConnection(m.connections[i], i, boxWidth))
+		}
+	}
+
+	list := lipgloss.JoinVertical(lipgloss.Left, boxes...)
+	scrollbar := renderScrollbar(lipgloss.Height(list), len(m.connections), start, end-start)
+	list = lipgloss.JoinHorizontal(lipgloss.Top, list, scrollbar)
+
+	if status := m.statusLine(); status != "" {
+		list = lipgloss.JoinVertical(lipgloss.Left, list, "  "+noStyle.Foreground(sbbGray).Render(status))
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, boxes...)
+	return list
 }
 
 func (m model) renderDetailedResult() string {
@@ -518,14 +974,23 @@ func (m model) renderDetailedResult() string {
 		return ""
 	}
 
-	boxWidth := m.width - borderSize*4 - m.resultBoxWidth()
-	return m.renderFullConnection(m.connections[m.resultIndex], boxWidth)
+	boxWidth := m.width - borderSize*4 - m.resultBoxWidth() - scrollbarWidth
+	if m.stacked() {
+		boxWidth = m.width - borderSize*3
+	}
+	c := m.connections[m.resultIndex]
+	return m.renderFullConnection(c, m.changes[connectionKey(c)], boxWidth)
 }
 
-func (m model) renderFullConnection(c models.Connection, width int) string {
+func (m model) renderFullConnection(c models.Connection, change connectionChange, width int) string {
 	var lines []string
 	innerWidth := width - borderSize - (fullConnPaddH * 2)
 
+	if warnings := connectionWarnings(c); len(warnings) > 0 {
+		lines = append(lines, renderWarnings(warnings, innerWidth)...)
+		lines = append(lines, "")
+	}
+
 	for i, section := range c.Sections {
 		isFirst := i == 0
 		isLast := i == len(c.Sections)-1
@@ -533,7 +998,7 @@ func (m model) renderFullConnection(c models.Connection, width int) string {
 		if section.Walk != nil {
 			lines = append(lines, m.renderWalkSection(section)...)
 		} else if section.Journey != nil {
-			lines = append(lines, m.renderJourneySection(section, innerWidth, isFirst, isLast)...)
+			lines = append(lines, m.renderJourneySection(section, change.section(i), innerWidth, isFirst, isLast, m.stopsExpanded(c, i))...)
 		}
 
 		if !isLast {
@@ -541,12 +1006,18 @@ func (m model) renderFullConnection(c models.Connection, width int) string {
 		}
 	}
 
-	content := strings.Join(lines, "\n")
+	// Cut before clipping, the box would wrap long lines below its end
+	for i, line := range lines {
+		lines[i] = truncateString(line, innerWidth)
+	}
+
+	// The height of the box counts its padding
 	boxHeight := m.resultsHeight() - borderSize - (fullConnPaddV * 2)
+	content := strings.Join(clipLines(lines, boxHeight-fullConnPaddV*2), "\n")
 	return detailedResultStyle.Width(width).Height(boxHeight).Render(content)
 }
 
-func (m model) renderJourneySection(section models.Section, width int, isFirst, isLast bool) []string {
+func (m model) renderJourneySection(section models.Section, change sectionChange, width int, isFirst, isLast, expanded bool) []string {
 	var lines []string
 
 	const timeCol = 5
@@ -557,44 +1028,58 @@ func (m model) renderJourneySection(section models.Section, width int, isFirst,
 	depTime := section.Departure.Departure.Local().Format("15:04")
 	depDelay := section.Departure.Delay
 	depStation := section.Departure.Station.Name
-	depPlatform := section.Departure.Platform
+	depPlatform := section.Departure.ExpectedPlatform()
 
 	depDot := hollowDot
 	if isFirst {
 		depDot = filledDot
 	}
 
-	depLine := m.formatStationLine(depTime, depDelay, depDot, depStation, depPlatform, width, timeCol, delayCol, symbolCol, true)
+	depLine := m.formatStationLine(depTime, depDelay, depDot, depStation, depPlatform, width, timeCol, delayCol, symbolCol, true, change.departure)
 	lines = append(lines, depLine)
 
 	indent := strings.Repeat(" ", timeCol+delayCol)
 	spacingLine := fmt.Sprintf("%s  %s", indent, vertLine)
 	lines = append(lines, spacingLine)
 
-	vehicleIcon := noStyle.Background(sbbBlue).Foreground(sbbWhite).Render(" " + vhcIcon + " ")
-	vehicleCategory := noStyle.Background(sbbRed).Foreground(sbbWhite).Bold(true).
-		Render(section.Journey.Category + " " + section.Journey.Number)
-	company := noStyle.Background(sbbWhite).Foreground(sbbBlack).
-		Render(section.Journey.Operator)
+	vehicleIcon := vehicleIconStyle.Render(" " + vhcIcon + " ")
+	vehicleCategory := vehicleCategoryStyle.Render(section.Journey.Category + " " + section.Journey.Number)
+	company := companyStyle.Render(section.Journey.Operator)
 	vehicleLine := fmt.Sprintf("%s  %s  %s %s %s", indent, vertLine, vehicleIcon, vehicleCategory, company)
-	lines = append(lines, vehicleLine)
-
-	destLine := fmt.Sprintf("%s  %s   → %s", indent, vertLine, section.Journey.To)
-	lines = append(lines, destLine)
+	if capacity := renderCapacities(sectionCapacity(section)); capacity != "" {
+		vehicleLine += "  " + capacity
+	}
+	railIndent := fmt.Sprintf("%s  %s   ", indent, vertLine)
+	lines = append(lines, wrapString(vehicleLine, width, railIndent)...)
+
+	destLine := fmt.Sprintf("%s  %s   %s %s", indent, vertLine, arrow, section.Journey.To)
+	stops := intermediateStops(section)
+	if len(stops) > 0 && !expanded {
+		hint := stopsHint(len(stops))
+		if k := firstKey(m.keys.Stops); k != "" {
+			hint += ", " + k + " to show"
+		}
+		destLine += noStyle.Foreground(sbbGray).Render("  (" + hint + ")")
+	}
+	lines = append(lines, wrapString(destLine, width, railIndent)...)
 
 	lines = append(lines, spacingLine)
+	if expanded {
+		lines = append(lines, m.renderStops(stops, width, timeCol, delayCol, symbolCol)...)
+		lines = append(lines, spacingLine)
+	}
 
 	arrTime := section.Arrival.Arrival.Local().Format("15:04")
 	arrDelay := section.Arrival.Delay
 	arrStation := section.Arrival.Station.Name
-	arrPlatform := section.Arrival.Platform
+	arrPlatform := section.Arrival.ExpectedPlatform()
 
 	arrSymbol := vertLine
 	if isLast {
 		arrSymbol = filledDot
 	}
 
-	arrLine := m.formatStationLine(arrTime, arrDelay, arrSymbol, arrStation, arrPlatform, width, timeCol, delayCol, symbolCol, false)
+	arrLine := m.formatStationLine(arrTime, arrDelay, arrSymbol, arrStation, arrPlatform, width, timeCol, delayCol, symbolCol, false, change.arrival)
 	lines = append(lines, arrLine)
 
 	return lines
@@ -633,7 +1118,7 @@ func (m model) renderWalkSection(section models.Section) []string {
 	return lines
 }
 
-func (m model) formatStationLine(timeStr string, delay int, symbol, station, platform string, width, timeCol, delayCol, symbolCol int, bold bool) string {
+func (m model) formatStationLine(timeStr string, delay int, symbol, station, platform string, width, timeCol, delayCol, symbolCol int, bold bool, change stopChange) string {
 	textStyle := noStyle
 	if bold {
 		textStyle = noStyle.Bold(true)
@@ -642,7 +1127,10 @@ func (m model) formatStationLine(timeStr string, delay int, symbol, station, pla
 	timePart := textStyle.Render(timeStr)
 
 	delayPart := ""
-	if delay > 0 {
+	if change.delay {
+		delayStr := fmt.Sprintf("+%d", delay)
+		delayPart = strings.Repeat(" ", max(delayCol-lipgloss.Width(delayStr), 0)) + changedStyle.Render(delayStr)
+	} else if delay > 0 {
 		delayStr := fmt.Sprintf("+%d", delay)
 		delayPart = noStyle.Foreground(sbbRed).Bold(true).Render(fmt.Sprintf("%*s", delayCol, delayStr))
 	} else {
@@ -652,20 +1140,23 @@ func (m model) formatStationLine(timeStr string, delay int, symbol, station, pla
 	symbolPart := fmt.Sprintf("  %s  ", symbol)
 
 	platformPart := ""
-	platformVisibleLen := 0
 	if platform != "" {
 		platformPart = textStyle.Render(fmt.Sprintf("%s %s", pltIcon, platform))
-		platformVisibleLen = len(platform) + 3
+		if change.platform {
+			platformPart = textStyle.Render(pltIcon+" ") + changedStyle.Render(platform)
+		}
 	}
 
-	fixedWidth := timeCol + delayCol + symbolCol + platformVisibleLen
+	// Measured in terminal cells, icons and names like Zürich are not one
+	// byte per cell
+	fixedWidth := max(timeCol, lipgloss.Width(timePart)) + max(delayCol, lipgloss.Width(delayPart)) +
+		max(symbolCol, lipgloss.Width(symbolPart)) + lipgloss.Width(platformPart)
 	availableForStation := max(width-fixedWidth-1, 5)
 
 	truncatedStation := truncateString(station, availableForStation)
 	stationPart := textStyle.Render(truncatedStation)
 
-	stationLen := len(truncatedStation)
-	padding := max(availableForStation-stationLen, 1)
+	padding := max(availableForStation-lipgloss.Width(truncatedStation), 1)
 
 	if platformPart != "" {
 		return fmt.Sprintf("%s%s%s%s%s%s",
@@ -674,17 +1165,33 @@ func (m model) formatStationLine(timeStr string, delay int, symbol, station, pla
 	return fmt.Sprintf("%s%s%s%s", timePart, delayPart, symbolPart, stationPart)
 }
 
+// truncateString shortens s to maxLen terminal cells, ending with an
+// ellipsis when there is room for one. Graphemes and styles stay intact.
 func truncateString(s string, maxLen int) string {
 	if maxLen <= 0 {
 		return ""
 	}
 	if maxLen <= 3 {
-		return s[:min(len(s), maxLen)]
+		return ansi.Truncate(s, maxLen, "")
 	}
-	if len(s) <= maxLen {
-		return s
+	return ansi.Truncate(s, maxLen, "...")
+}
+
+// wrapString breaks s into lines of at most width cells. Wrapped lines
+// continue after indent, which keeps them on the rail or under their text.
+func wrapString(s string, width int, indent string) []string {
+	lines := strings.Split(ansi.Wrap(s, width, ""), "\n")
+	rest := width - lipgloss.Width(indent)
+	if len(lines) == 1 || rest < 1 {
+		return lines
+	}
+
+	tail := ansi.Wrap(strings.Join(lines[1:], " "), rest, "")
+	wrapped := lines[:1]
+	for _, line := range strings.Split(tail, "\n") {
+		wrapped = append(wrapped, indent+line)
 	}
-	return s[:maxLen-3] + "..."
+	return wrapped
 }
 
 func (m model) renderSimpleConnection(c models.Connection, index int, width int) string {
@@ -696,36 +1203,47 @@ func (m model) renderSimpleConnection(c models.Connection, index int, width int)
 		}
 	}
 
-	vehicleIcon := noStyle.Background(sbbBlue).Foreground(sbbWhite).Render(" " + vhcIcon + " ")
-	vehicleCategory := noStyle.Background(sbbRed).Foreground(sbbWhite).Bold(true).
-		Render(c.Sections[firstVehicle].Journey.Category + " " + c.Sections[firstVehicle].Journey.Number)
-	company := noStyle.Background(sbbWhite).Foreground(sbbBlack).
-		Render(c.Sections[firstVehicle].Journey.Operator)
+	vehicleIcon := vehicleIconStyle.Render(" " + vhcIcon + " ")
+	vehicleCategory := vehicleCategoryStyle.Render(c.Sections[firstVehicle].Journey.Category + " " + c.Sections[firstVehicle].Journey.Number)
+	company := companyStyle.Render(c.Sections[firstVehicle].Journey.Operator)
 	endStop := noStyle.Render(c.Sections[firstVehicle].Journey.To)
+	if m.isWatched(c) {
+		endStop += "  " + noStyle.Foreground(sbbRed).Render(wtchIcon)
+	}
+	if badge := renderWarningBadge(c, connectionWarnings(c)); badge != "" {
+		endStop += "  " + badge
+	}
 
 	dep := c.FromData.Departure.Local().Format("15:04")
 	arr := c.ToData.Arrival.Local().Format("15:04")
 	departure := noStyle.Bold(true).Render(dep)
 	arrival := noStyle.Bold(true).Render(arr)
 
-	departureDelay := formatDelay(c.Sections[firstVehicle].Departure.Delay)
-	arrivalDelay := formatDelay(c.Sections[firstVehicle].Arrival.Delay)
+	change := m.changes[connectionKey(c)]
+	departureDelay := formatDelay(c.Sections[firstVehicle].Departure.Delay, change.section(firstVehicle).departure.delay)
+	arrivalDelay := formatDelay(c.Sections[firstVehicle].Arrival.Delay, change.section(firstVehicle).arrival.delay)
 
 	stopsLineWidth := max(width-stopsLineFixedWidth, stopsLineMinWidth)
 	stopsLine := noStyle.Bold(true).Render(renderStopsLine(c, stopsLineWidth))
 
 	platformOrWalk := ""
-	if len(c.FromData.Platform) > 0 {
-		platformOrWalk = pltIcon + " " + noStyle.Render(c.FromData.Platform)
+	if platform := departurePlatform(c); len(platform) > 0 {
+		platformOrWalk = pltIcon + " " + noStyle.Render(platform)
+		if change.platform {
+			platformOrWalk = pltIcon + " " + changedStyle.Render(platform)
+		}
 	} else if c.Sections[0].Walk != nil {
 		platformOrWalk = wlkIcon + " " + noStyle.Render(
 			fmt.Sprintf("%vm", c.Sections[0].Arrival.Arrival.Sub(c.Sections[0].Departure.Departure).Minutes()),
 		)
 	}
 
-	duration := noStyle.Render(formatDuration(c.Duration))
+	duration := noStyle.Render(utils.FormatDuration(c.Duration))
 
-	bottomLinePadding := max(width-(borderSize*2+smplConnMrgn*2+smplConnMrgn*2+3+5), 1)
+	if capacity := renderCapacities(connectionCapacity(c)); capacity != "" {
+		duration = capacity + "  " + duration
+	}
+	bottomLinePadding := max(width-(borderSize*2+smplConnMrgn*2+smplConnMrgn*2)-lipgloss.Width(platformOrWalk)-lipgloss.Width(duration), 1)
 
 	content := fmt.Sprintf("\n  %s %s %s  %s\n\n  %s%s  %s  %s%s\n\n  %s%s%v\n",
 		vehicleIcon,
@@ -750,22 +1268,12 @@ func (m model) renderSimpleConnection(c models.Connection, index int, width int)
 	return style.Render(content)
 }
 
-// 00d01:15:00" -> "1h 15m" or "15 min".
-func formatDuration(duration string) string {
-	parts := strings.Split(duration, ":")
-	if len(parts) < 2 {
-		return duration
-	}
-
-	minutes := parts[1]
-	if len(parts[0]) > 3 && parts[0][3:] != "00" {
-		hours := parts[0][3:]
-		return hours + "h " + minutes + "m"
+// formatDelay renders the delay next to a time, a delay that just changed
+// stands out even when it dropped back to zero.
+func formatDelay(delay int, changed bool) string {
+	if changed {
+		return " " + changedStyle.Render(fmt.Sprintf("+%d", delay))
 	}
-	return minutes + "min"
-}
-
-func formatDelay(delay int) string {
 	if delay > 0 {
 		return noStyle.Foreground(sbbRed).Bold(true).Render(fmt.Sprintf(" +%d", delay))
 	}
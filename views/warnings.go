@@ -0,0 +1,141 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"sbb-tui/models"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type warning struct {
+	severe bool
+	text   string
+}
+
+// connectionWarnings collects what the traveller should know beyond the
+// timetable: cancellations, disruptions, platform changes and delays that
+// build up along the way.
+func connectionWarnings(c models.Connection) []warning {
+	var warnings []warning
+
+	for _, s := range c.Sections {
+		if s.Journey == nil {
+			continue
+		}
+		name := strings.TrimSpace(s.Journey.Category + " " + s.Journey.Number)
+
+		if s.Journey.Cancelled {
+			warnings = append(warnings, warning{severe: true, text: name + " is cancelled"})
+		}
+
+		if p := s.Departure.Prognosis; p != nil && p.Platform != "" && p.Platform != s.Departure.Platform {
+			warnings = append(warnings, warning{
+				severe: true,
+				text:   fmt.Sprintf("%s leaves %s from platform %s", name, s.Departure.Station.Name, p.Platform),
+			})
+		}
+		if p := s.Arrival.Prognosis; p != nil && p.Platform != "" && p.Platform != s.Arrival.Platform {
+			warnings = append(warnings, warning{
+				text: fmt.Sprintf("%s arrives at %s on platform %s", name, s.Arrival.Station.Name, p.Platform),
+			})
+		}
+
+		for _, stop := range s.Journey.PassList {
+			if stop.Cancelled && stop.Station.Name != "" {
+				warnings = append(warnings, warning{
+					severe: true,
+					text:   fmt.Sprintf("%s does not stop at %s", name, stop.Station.Name),
+				})
+			}
+		}
+
+		if stop, delay := maxPassListDelay(s); delay > s.Departure.Delay {
+			warnings = append(warnings, warning{
+				text: fmt.Sprintf("%s expected %d min late at %s", name, delay, stop),
+			})
+		}
+	}
+
+	for _, d := range c.Disruptions {
+		text := strings.TrimSpace(strings.Join(nonEmpty(d.Header, d.Lead), ": "))
+		if text == "" {
+			text = strings.TrimSpace(d.Text)
+		}
+		if text != "" {
+			warnings = append(warnings, warning{severe: true, text: text})
+		}
+	}
+
+	if c.Service != nil && c.Service.Irregular != "" {
+		warnings = append(warnings, warning{text: "Irregular service: " + c.Service.Irregular})
+	}
+
+	return warnings
+}
+
+// maxPassListDelay returns the intermediate stop with the largest expected
+// delay of the section.
+func maxPassListDelay(s models.Section) (string, int) {
+	station, delay := "", 0
+	for _, stop := range s.Journey.PassList {
+		if stop.Delay > delay {
+			station, delay = stop.Station.Name, stop.Delay
+		}
+	}
+	return station, delay
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func isCancelled(c models.Connection) bool {
+	for _, s := range c.Sections {
+		if s.Journey != nil && s.Journey.Cancelled {
+			return true
+		}
+	}
+	return false
+}
+
+// renderWarningBadge marks a connection in the list, empty when there is
+// nothing to warn about.
+func renderWarningBadge(c models.Connection, warnings []warning) string {
+	if isCancelled(c) {
+		return warningStyle.Render(" " + wrnIcon + " Cancelled ")
+	}
+
+	for _, w := range warnings {
+		if w.severe {
+			return warningStyle.Render(" " + wrnIcon + " ")
+		}
+	}
+	if len(warnings) > 0 {
+		return noStyle.Foreground(sbbRed).Render(wrnIcon)
+	}
+	return ""
+}
+
+// renderWarnings is the message block heading the detailed view.
+func renderWarnings(warnings []warning, width int) []string {
+	var lines []string
+	for _, w := range warnings {
+		style := noStyle.Width(width)
+		if w.severe {
+			style = style.Foreground(sbbRed).Bold(true)
+		}
+		indent := strings.Repeat(" ", lipgloss.Width(wrnIcon)+1)
+		for _, line := range wrapString(wrnIcon+" "+w.text, width, indent) {
+			lines = append(lines, style.Render(line))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,230 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"sbb-tui/config"
+	"sbb-tui/models"
+	"sbb-tui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How many connections to ask for when polling the watched one
+const watchLimit = 4
+
+type watchTickMsg struct {
+	id int
+}
+
+type WatchMsg struct {
+	id          int
+	connections []models.Connection
+	err         error
+}
+
+// watch follows a single connection independently of the current search.
+type watch struct {
+	key          string
+	connection   models.Connection
+	input        models.Input
+	alertedDelay int
+}
+
+type alert struct {
+	title   string
+	message string
+}
+
+// toggleWatch starts or stops watching the selected connection.
+func (m *model) toggleWatch() tea.Cmd {
+	if len(m.connections) == 0 {
+		return nil
+	}
+
+	c := m.connections[m.resultIndex]
+	key := connectionKey(c)
+	m.watchID++
+	if m.watched != nil && m.watched.key == key {
+		m.watched = nil
+		return nil
+	}
+
+	m.watched = &watch{
+		key:          key,
+		connection:   c,
+		input:        watchInput(c, m.query),
+		alertedDelay: departureDelay(c),
+	}
+	return m.scheduleWatch()
+}
+
+// watchInput searches for the watched connection itself, so it is found no
+// matter which page of the results it came from.
+func watchInput(c models.Connection, query models.Input) models.Input {
+	departure := c.FromData.Departure.Local()
+	return models.Input{
+		From:            c.FromData.Station.Name,
+		To:              c.ToData.Station.Name,
+		Via:             query.Via,
+		Date:            departure,
+		Time:            departure,
+		Transportations: query.Transportations,
+		Limit:           watchLimit,
+	}
+}
+
+func (m model) scheduleWatch() tea.Cmd {
+	id := m.watchID
+	return tea.Tick(m.watchInterval, func(time.Time) tea.Msg {
+		return watchTickMsg{id: id}
+	})
+}
+
+func (m *model) handleWatchTick(msg watchTickMsg) tea.Cmd {
+	if msg.id != m.watchID || m.watched == nil {
+		return nil
+	}
+
+	// Nothing left to watch once the train is gone
+	c := m.watched.connection
+	departure := c.FromData.Departure.Add(time.Duration(departureDelay(c)) * time.Minute)
+	if time.Now().After(departure) {
+		m.watched = nil
+		return nil
+	}
+
+	provider, id, input := m.provider, m.watchID, m.watched.input
+	return func() tea.Msg {
+		res, err := provider.Connections(context.Background(), input)
+		return WatchMsg{id: id, connections: res, err: err}
+	}
+}
+
+// updateWatch compares the polled connection with the last known state and
+// notifies about a delay above the threshold or a platform change.
+func (m *model) updateWatch(msg WatchMsg) tea.Cmd {
+	if msg.id != m.watchID || m.watched == nil {
+		return nil
+	}
+	if msg.err != nil {
+		return m.scheduleWatch()
+	}
+
+	w := m.watched
+	var fresh *models.Connection
+	for i, c := range msg.connections {
+		if connectionKey(c) == w.key {
+			fresh = &msg.connections[i]
+			break
+		}
+	}
+	if fresh == nil {
+		return m.scheduleWatch()
+	}
+
+	var alerts []alert
+	name := journeyName(*fresh)
+
+	delay := departureDelay(*fresh)
+	if delay >= m.delayThreshold && delay > w.alertedDelay {
+		alerts = append(alerts, alert{
+			title: fmt.Sprintf("%s is %d min late", name, delay),
+			message: fmt.Sprintf("Departs %s +%d from %s",
+				fresh.FromData.Departure.Local().Format("15:04"), delay, fresh.FromData.Station.Name),
+		})
+	}
+	// A dropping delay re-arms the alert for the next rise
+	if delay > w.alertedDelay || delay < m.delayThreshold {
+		w.alertedDelay = delay
+	}
+
+	for i := range min(len(w.connection.Sections), len(fresh.Sections)) {
+		old, s := w.connection.Sections[i], fresh.Sections[i]
+		if p, now := old.Departure.ExpectedPlatform(), s.Departure.ExpectedPlatform(); p != "" && p != now {
+			alerts = append(alerts, platformAlert(s.Departure.Station.Name, p, now))
+		}
+		if p, now := old.Arrival.ExpectedPlatform(), s.Arrival.ExpectedPlatform(); p != "" && p != now {
+			alerts = append(alerts, platformAlert(s.Arrival.Station.Name, p, now))
+		}
+	}
+
+	w.connection = *fresh
+	m.connections = slices.Clone(m.connections)
+	for i, c := range m.connections {
+		if connectionKey(c) == w.key {
+			m.connections[i] = *fresh
+		}
+	}
+
+	return tea.Batch(m.notifyCmd(alerts), m.scheduleWatch())
+}
+
+func platformAlert(station, from, to string) alert {
+	return alert{
+		title:   "Platform change at " + station,
+		message: fmt.Sprintf("Now platform %s instead of %s", to, from),
+	}
+}
+
+func newNotifier(cfg config.Watch) notify.Notifier {
+	var notifiers notify.Multi
+	if cfg.Bell {
+		notifiers = append(notifiers, notify.Bell{W: os.Stderr})
+	}
+	if cfg.Desktop {
+		notifiers = append(notifiers, notify.Desktop{AppName: "sbb-tui"})
+	}
+	return notifiers
+}
+
+func (m model) notifyCmd(alerts []alert) tea.Cmd {
+	if len(alerts) == 0 || m.notifier == nil {
+		return nil
+	}
+
+	notifier := m.notifier
+	return func() tea.Msg {
+		for _, a := range alerts {
+			// Best effort, there is no one to tell when notifying fails
+			_ = notifier.Notify(a.title, a.message)
+		}
+		return nil
+	}
+}
+
+func (m model) isWatched(c models.Connection) bool {
+	return m.watched != nil && m.watched.key == connectionKey(c)
+}
+
+func (m model) watchStatus() string {
+	if m.watched == nil {
+		return ""
+	}
+	c := m.watched.connection
+	return fmt.Sprintf("Watching %s, %s %s %s %s",
+		journeyName(c), c.FromData.Departure.Local().Format("15:04"), c.FromData.Station.Name, arrow, c.ToData.Station.Name)
+}
+
+// departureDelay is the delay of the first vehicle, as shown in the list.
+func departureDelay(c models.Connection) int {
+	for _, s := range c.Sections {
+		if s.Journey != nil {
+			return s.Departure.Delay
+		}
+	}
+	return c.FromData.Delay
+}
+
+func journeyName(c models.Connection) string {
+	for _, s := range c.Sections {
+		if s.Journey != nil {
+			return s.Journey.Category + " " + s.Journey.Number
+		}
+	}
+	return "Your connection"
+}
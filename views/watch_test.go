@@ -0,0 +1,32 @@
+package views
+
+import (
+	"testing"
+
+	"sbb-tui/models"
+)
+
+type recordingNotifier struct {
+	titles *[]string
+}
+
+func (n recordingNotifier) Notify(title, message string) error {
+	*n.titles = append(*n.titles, title)
+	return nil
+}
+
+func TestWatchPrognosisPlatform(t *testing.T) {
+	var titles []string
+	m := newTestModel(t, 160, 40)
+	m.notifier = recordingNotifier{&titles}
+	m.connections = fixtureConnections(t)[:1]
+	m.toggleWatch()
+
+	fresh := fixtureConnections(t)[:1]
+	fresh[0].Sections[0].Departure.Prognosis = &models.Prognosis{Platform: "12"}
+	run(m.updateWatch(WatchMsg{id: m.watchID, connections: fresh}))
+
+	if len(titles) != 1 || titles[0] != "Platform change at Bern" {
+		t.Errorf("alerts = %q, want the platform change at Bern", titles)
+	}
+}
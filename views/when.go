@@ -0,0 +1,62 @@
+package views
+
+import (
+	"regexp"
+	"time"
+
+	"sbb-tui/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	// Values getting an automatic separator on the next digit
+	isoDatePrefix = regexp.MustCompile(`^\d{4}(-\d{2})?$`)
+	clockPrefix   = regexp.MustCompile(`^\d{2}$`)
+
+	// Values already in the form the preview would show
+	isoDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	clock   = regexp.MustCompile(`^\d{2}:\d{2}$`)
+)
+
+func isDigit(msg tea.KeyMsg) bool {
+	s := msg.String()
+	return msg.Type == tea.KeyRunes && len(s) == 1 && s[0] >= '0' && s[0] <= '9'
+}
+
+// when resolves the date and time inputs, returning an error message for
+// the field that cannot be understood.
+func (m model) when(now time.Time) (utils.When, string) {
+	date, err := utils.ParseDate(m.inputs[2].Value(), now)
+	if err != nil {
+		return utils.When{}, "Please enter a valid date, e.g. 2025-10-16, 16.10., tomorrow or fri."
+	}
+	t, err := utils.ParseWhen(m.inputs[3].Value(), now)
+	if err != nil {
+		return utils.When{}, "Please enter a valid time, e.g. 18:30, now, +45m or in 2h."
+	}
+	return date.Merge(t), ""
+}
+
+// whenPreview shows what the focused date or time field resolves to, unless
+// it already holds the plain value.
+func (m model) whenPreview() (string, string) {
+	if m.tabIndex >= len(m.headerOrder) {
+		return "", ""
+	}
+	id := m.headerOrder[m.tabIndex].id
+	if id != "date" && id != "time" {
+		return "", ""
+	}
+
+	date, t := m.inputs[2].Value(), m.inputs[3].Value()
+	if (date == "" || isoDate.MatchString(date)) && (t == "" || clock.MatchString(t)) {
+		return "", ""
+	}
+
+	when, msg := m.when(time.Now())
+	if msg != "" {
+		return "", ""
+	}
+	return suggestionStyle.Render(arrow + " " + when.String()), id
+}